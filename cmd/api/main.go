@@ -19,6 +19,8 @@ func main() {
 	cfg.Limiter.RPS = c.RateLimiter.RPS
 	cfg.Limiter.Burst = c.RateLimiter.Burst
 	cfg.Limiter.Enabled = c.RateLimiter.Enabled
+	cfg.Jobs.MonthlyStatements = c.Jobs.MonthlyStatements
+	cfg.Jobs.GoalContributions = c.Jobs.GoalContributions
 
 	app := api.NewApp(cfg)
 	err := app.Serve()
@@ -11,6 +11,7 @@ type Conf struct {
 	DB          ConfDB
 	RateLimiter ConfRL
 	Security    ConfSecurity
+	Jobs        ConfJobs
 }
 
 type ConfServer struct {
@@ -35,6 +36,11 @@ type ConfSecurity struct {
 	SecretKey string `env:"SECRET_KEY,required"`
 }
 
+type ConfJobs struct {
+	MonthlyStatements bool `env:"JOBS_MONTHLY_STATEMENTS,default=false"`
+	GoalContributions bool `env:"JOBS_GOAL_CONTRIBUTIONS,default=false"`
+}
+
 func New() *Conf {
 	var c Conf
 	if err := envdecode.StrictDecode(&c); err != nil {
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"financas/internal/model"
+	"financas/internal/service"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func (app *application) background(fn func()) {
+	app.wg.Add(1)
+
+	go func() {
+		defer app.wg.Done()
+
+		defer func() {
+			if err := recover(); err != nil {
+				app.Logger.PrintError(fmt.Errorf("%s", err), nil)
+			}
+		}()
+
+		fn()
+	}()
+}
+
+func (app *application) scheduleMonthlyStatements(statement service.StatementServiceInterface, quit <-chan struct{}) {
+	app.background(func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		lastRun := ""
+
+		for {
+			now := time.Now().UTC()
+
+			if now.Day() == 1 && now.Format(model.StatementMonthLayout) != lastRun {
+				month := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+
+				generated, err := statement.GenerateForAllUsers(month)
+				if err != nil {
+					app.Logger.PrintError(err, map[string]string{
+						"job":   "monthly_statements",
+						"month": month.Format(model.StatementMonthLayout),
+					})
+				} else {
+					lastRun = now.Format(model.StatementMonthLayout)
+					app.Logger.PrintInfo("monthly statements generated", map[string]string{
+						"month":     month.Format(model.StatementMonthLayout),
+						"generated": strconv.Itoa(generated),
+					})
+				}
+			}
+
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+func (app *application) scheduleGoalContributions(schedule service.GoalScheduleServiceInterface, quit <-chan struct{}) {
+	app.background(func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			now := time.Now().UTC()
+
+			executed, skipped, err := schedule.RunDue(now)
+			if err != nil {
+				app.Logger.PrintError(err, map[string]string{
+					"job":  "goal_contributions",
+					"date": now.Format(model.DateLayout),
+				})
+			}
+
+			if executed > 0 || skipped > 0 {
+				app.Logger.PrintInfo("goal contributions processed", map[string]string{
+					"date":     now.Format(model.DateLayout),
+					"executed": strconv.Itoa(executed),
+					"skipped":  strconv.Itoa(skipped),
+				})
+			}
+
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
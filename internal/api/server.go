@@ -34,11 +34,20 @@ func (app *application) Serve() error {
 	}
 
 	shutdownError := make(chan error)
+	quit := make(chan struct{})
+
+	if app.config.Jobs.MonthlyStatements {
+		app.scheduleMonthlyStatements(r.Handler.Service.Statement, quit)
+	}
+
+	if app.config.Jobs.GoalContributions {
+		app.scheduleGoalContributions(r.Handler.Service.GoalSchedule, quit)
+	}
 
 	go func() {
-		quit := make(chan os.Signal, 1)
-		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-		s := <-quit
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		s := <-sig
 
 		app.Logger.PrintInfo("shutting down", map[string]string{
 			"signal": s.String(),
@@ -58,6 +67,7 @@ func (app *application) Serve() error {
 			"addr": srv.Addr,
 		})
 
+		close(quit)
 		app.wg.Wait()
 		shutdownError <- nil
 	}()
@@ -0,0 +1,252 @@
+package chart
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const ContentType = "image/svg+xml"
+
+var colorRX = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[a-zA-Z]+)$`)
+
+var palette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+const (
+	fontFamily = "Helvetica, Arial, sans-serif"
+	textColor  = "#333333"
+	gridColor  = "#e0e0e0"
+)
+
+type Slice struct {
+	Label string
+	Value float64
+	Color string
+}
+
+type Series struct {
+	Name  string
+	Color string
+}
+
+type BarGroup struct {
+	Label  string
+	Values []float64
+}
+
+func Color(color string, index int) string {
+	if colorRX.MatchString(color) {
+		return color
+	}
+	return palette[index%len(palette)]
+}
+
+func Donut(title, center string, slices []Slice) []byte {
+	const (
+		radius = 80.0
+		stroke = 40.0
+		cx     = 130.0
+		cy     = 150.0
+		width  = 520.0
+	)
+
+	height := math.Max(300, 70+float64(len(slices))*22)
+	circumference := 2 * math.Pi * radius
+
+	total := 0.0
+	for _, s := range slices {
+		total += math.Max(s.Value, 0)
+	}
+
+	var b strings.Builder
+	open(&b, width, height)
+	titleText(&b, title)
+
+	if total == 0 {
+		fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="%s" fill="none" stroke="%s" stroke-width="%s"/>`,
+			num(cx), num(cy), num(radius), gridColor, num(stroke))
+	}
+
+	offset := 0.0
+	for i, s := range slices {
+		if s.Value <= 0 || total == 0 {
+			continue
+		}
+
+		length := s.Value / total * circumference
+		fmt.Fprintf(&b,
+			`<circle cx="%s" cy="%s" r="%s" fill="none" stroke="%s" stroke-width="%s" stroke-dasharray="%s %s" stroke-dashoffset="%s" transform="rotate(-90 %s %s)"><title>%s</title></circle>`,
+			num(cx), num(cy), num(radius), Color(s.Color, i), num(stroke),
+			num(length), num(circumference-length), num(-offset), num(cx), num(cy), escape(s.Label),
+		)
+		offset += length
+	}
+
+	fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="middle" font-size="14" font-weight="bold">%s</text>`,
+		num(cx), num(cy+5), escape(center))
+
+	for i, s := range slices {
+		y := 60 + float64(i)*22
+		percentage := 0.0
+		if total > 0 {
+			percentage = math.Max(s.Value, 0) / total * 100
+		}
+
+		fmt.Fprintf(&b, `<rect x="270" y="%s" width="14" height="14" rx="2" fill="%s"/>`, num(y), Color(s.Color, i))
+		fmt.Fprintf(&b, `<text x="292" y="%s" font-size="12">%s (%s%%)</text>`,
+			num(y+12), escape(s.Label), strconv.FormatFloat(percentage, 'f', 1, 64))
+	}
+
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+func Bars(title string, series []Series, groups []BarGroup) []byte {
+	const (
+		width  = 680.0
+		height = 340.0
+		left   = 70.0
+		right  = 20.0
+		top    = 60.0
+		bottom = 40.0
+		ticks  = 5
+	)
+
+	plotWidth := width - left - right
+	plotHeight := height - top - bottom
+
+	maxValue := 0.0
+	for _, g := range groups {
+		for _, v := range g.Values {
+			maxValue = math.Max(maxValue, v)
+		}
+	}
+	maxValue = niceCeil(maxValue)
+
+	var b strings.Builder
+	open(&b, width, height)
+	titleText(&b, title)
+
+	x := left
+	for i, s := range series {
+		fmt.Fprintf(&b, `<rect x="%s" y="36" width="12" height="12" rx="2" fill="%s"/>`, num(x), Color(s.Color, i))
+		fmt.Fprintf(&b, `<text x="%s" y="46" font-size="12">%s</text>`, num(x+18), escape(s.Name))
+		x += 30 + float64(len(s.Name))*7
+	}
+
+	for i := 0; i <= ticks; i++ {
+		value := maxValue * float64(i) / ticks
+		y := top + plotHeight - plotHeight*float64(i)/ticks
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`, num(left), num(y), num(width-right), num(y), gridColor)
+		fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="end" font-size="11">%s</text>`, num(left-8), num(y+4), label(value))
+	}
+
+	if len(groups) > 0 && len(series) > 0 {
+		groupWidth := plotWidth / float64(len(groups))
+		barWidth := groupWidth * 0.7 / float64(len(series))
+
+		for i, g := range groups {
+			gx := left + groupWidth*float64(i) + groupWidth*0.15
+
+			for j, v := range g.Values {
+				if j >= len(series) {
+					break
+				}
+
+				h := 0.0
+				if maxValue > 0 {
+					h = math.Max(v, 0) / maxValue * plotHeight
+				}
+
+				fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"><title>%s: %s</title></rect>`,
+					num(gx+barWidth*float64(j)), num(top+plotHeight-h), num(barWidth), num(h),
+					Color(series[j].Color, j), escape(series[j].Name), strconv.FormatFloat(v, 'f', 2, 64))
+			}
+
+			fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="middle" font-size="11">%s</text>`,
+				num(left+groupWidth*(float64(i)+0.5)), num(height-bottom+18), escape(g.Label))
+		}
+	}
+
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+func Progress(title string, current, target float64, caption, color string) []byte {
+	const (
+		width  = 420.0
+		height = 80.0
+		barX   = 10.0
+		barY   = 36.0
+		barW   = 400.0
+		barH   = 18.0
+	)
+
+	ratio := 0.0
+	if target > 0 {
+		ratio = math.Min(math.Max(current/target, 0), 1)
+	}
+
+	var b strings.Builder
+	open(&b, width, height)
+	fmt.Fprintf(&b, `<text x="%s" y="24" font-size="14" font-weight="bold">%s</text>`, num(barX), escape(title))
+	fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" rx="9" fill="%s"/>`, num(barX), num(barY), num(barW), num(barH), gridColor)
+	if ratio > 0 {
+		fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" rx="9" fill="%s"/>`,
+			num(barX), num(barY), num(barW*ratio), num(barH), Color(color, 0))
+	}
+	fmt.Fprintf(&b, `<text x="%s" y="%s" font-size="12">%s</text>`, num(barX), num(barY+barH+18), escape(caption))
+	b.WriteString(`</svg>`)
+
+	return []byte(b.String())
+}
+
+func open(b *strings.Builder, width, height float64) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="%s" fill="%s">`,
+		num(width), num(height), num(width), num(height), fontFamily, textColor)
+}
+
+func titleText(b *strings.Builder, title string) {
+	fmt.Fprintf(b, `<text x="10" y="24" font-size="16" font-weight="bold">%s</text>`, escape(title))
+}
+
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, step := range []float64{1, 2, 2.5, 5, 10} {
+		if step*magnitude >= v {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+func label(v float64) string {
+	switch {
+	case v >= 1_000_000:
+		return strconv.FormatFloat(v/1_000_000, 'f', -1, 64) + "M"
+	case v >= 1_000:
+		return strconv.FormatFloat(v/1_000, 'f', -1, 64) + "k"
+	default:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
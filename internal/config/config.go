@@ -20,4 +20,8 @@ type Config struct {
 	Security struct {
 		SecretKey string
 	}
+	Jobs struct {
+		MonthlyStatements bool
+		GoalContributions bool
+	}
 }
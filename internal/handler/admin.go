@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"net/http"
+)
+
+type AdminHandler struct {
+	admin          service.AdminServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type AdminHandlerInterface interface {
+	GetAllUsers(w http.ResponseWriter, r *http.Request)
+	DeactivateUser(w http.ResponseWriter, r *http.Request)
+	ReactivateUser(w http.ResponseWriter, r *http.Request)
+	GetSystemStats(w http.ResponseWriter, r *http.Request)
+}
+
+func NewAdminHandler(
+	admin service.AdminServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *AdminHandler {
+	return &AdminHandler{
+		admin:          admin,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *AdminHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string
+		filters.Filters
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Name = utils.ReadString(qs, "name", "")
+	input.Filters.Page = utils.ReadInt(qs, "page", 1, v)
+	input.Filters.PageSize = utils.ReadInt(qs, "page_size", 20, v)
+	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
+	input.Filters.SortSafelist = []string{"id", "name", "email", "created_at", "-id", "-name", "-email", "-created_at"}
+
+	if filters.ValidateFilters(v, input.Filters); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	users, metadata, err := h.admin.GetAllUsers(v, input.Name, input.Filters)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	usersDTO := make([]*model.UserAdminDTO, 0, len(users))
+	for _, u := range users {
+		usersDTO = append(usersDTO, u.ToAdminDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"users": usersDTO, "metadata": metadata}, nil, h.errRsp)
+}
+
+func (h *AdminHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.setUserActivated(w, r, false)
+}
+
+func (h *AdminHandler) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.setUserActivated(w, r, true)
+}
+
+func (h *AdminHandler) setUserActivated(w http.ResponseWriter, r *http.Request, activated bool) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	admin := h.contextGetUser(r)
+	user, err := h.admin.SetUserActivated(id, admin.ID, activated)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"user": user.ToAdminDTO()}, nil, h.errRsp)
+}
+
+func (h *AdminHandler) GetSystemStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.admin.GetSystemStats()
+	if err != nil {
+		h.errRsp.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"stats": stats}, nil, h.errRsp)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+)
+
+type AssetHandler struct {
+	asset          service.AssetServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type AssetHandlerInterface interface {
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	AddValuation(w http.ResponseWriter, r *http.Request)
+	DeleteValuation(w http.ResponseWriter, r *http.Request)
+}
+
+func NewAssetHandler(
+	asset service.AssetServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *AssetHandler {
+	return &AssetHandler{
+		asset:          asset,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *AssetHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string
+		filters.Filters
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Name = utils.ReadString(qs, "name", "")
+	input.Filters.Page = utils.ReadInt(qs, "page", 1, v)
+	input.Filters.PageSize = utils.ReadInt(qs, "page_size", 20, v)
+	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
+	input.Filters.SortSafelist = []string{"id", "name", "class", "-id", "-name", "-class"}
+
+	if filters.ValidateFilters(v, input.Filters); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	assets, metadata, err := h.asset.GetAll(v, input.Name, user.ID, input.Filters)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	assetsDTO := make([]*model.AssetDTO, 0, len(assets))
+	for _, asset := range assets {
+		assetsDTO = append(assetsDTO, asset.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"assets": assetsDTO, "metadata": metadata}, nil, h.errRsp)
+}
+
+func (h *AssetHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	asset, err := h.asset.GetByID(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	dto := asset.ToDTO()
+	dto.Valuations = make([]*model.AssetValuationDTO, 0, len(asset.Valuations))
+	for _, valuation := range asset.Valuations {
+		dto.Valuations = append(dto.Valuations, valuation.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"asset": dto}, nil, h.errRsp)
+}
+
+func (h *AssetHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dto model.AssetDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	asset := dto.ToModel()
+	asset.User = user
+
+	if asset.Currency == "" {
+		asset.Currency = user.BaseCurrency
+	}
+
+	if err := h.asset.Insert(v, asset); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/assets/%d", asset.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"asset": asset.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *AssetHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.AssetDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	dto.ID = &id
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	asset := dto.ToModel()
+	asset.User = user
+
+	if err := h.asset.Update(v, asset); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"asset": asset.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *AssetHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.asset.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *AssetHandler) AddValuation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.AssetValuationDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	valuation := dto.ToModel()
+
+	if err := h.asset.AddValuation(v, id, user.ID, valuation); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"valuation": valuation.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *AssetHandler) DeleteValuation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	valuationID, err := utils.ReadIntParam(r, "valuationID")
+	if err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.asset.DeleteValuation(valuationID, id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
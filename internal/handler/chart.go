@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"financas/internal/chart"
+	"financas/internal/model"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"net/http"
+	"strings"
+)
+
+type ChartHandler struct {
+	chart          service.ChartServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type ChartHandlerInterface interface {
+	GetCategoryChart(w http.ResponseWriter, r *http.Request)
+	GetTrendChart(w http.ResponseWriter, r *http.Request)
+	GetGoalChart(w http.ResponseWriter, r *http.Request)
+}
+
+func NewChartHandler(
+	chart service.ChartServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *ChartHandler {
+	return &ChartHandler{
+		chart:          chart,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *ChartHandler) GetCategoryChart(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	startDate := utils.ReadDate(qs, "start", "2006-01-02")
+	endDate := utils.ReadDate(qs, "end", "2006-01-02")
+	categoryType := strings.ToUpper(utils.ReadString(qs, "type", model.DESPESA.String()))
+
+	svg, err := h.chart.GetCategoryChart(v, user.ID, startDate, endDate, categoryType)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	writeSVG(w, svg)
+}
+
+func (h *ChartHandler) GetTrendChart(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+
+	svg, err := h.chart.GetTrendChart(v, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	writeSVG(w, svg)
+}
+
+func (h *ChartHandler) GetGoalChart(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	v := validator.New()
+
+	svg, err := h.chart.GetGoalChart(v, user.ID, id)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	writeSVG(w, svg)
+}
+
+func writeSVG(w http.ResponseWriter, svg []byte) {
+	w.Header().Set("Content-Type", chart.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=60")
+	w.WriteHeader(http.StatusOK)
+	w.Write(svg)
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+)
+
+type ExchangeRateHandler struct {
+	rate           service.ExchangeRateServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type ExchangeRateHandlerInterface interface {
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Import(w http.ResponseWriter, r *http.Request)
+}
+
+func NewExchangeRateHandler(
+	rate service.ExchangeRateServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		rate:           rate,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *ExchangeRateHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Currency string
+		filters.Filters
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Currency = utils.ReadString(qs, "currency", "")
+	input.Filters.Page = utils.ReadInt(qs, "page", 1, v)
+	input.Filters.PageSize = utils.ReadInt(qs, "page_size", 20, v)
+	input.Filters.Sort = utils.ReadString(qs, "sort", "-date")
+	input.Filters.SortSafelist = []string{"id", "date", "rate", "-id", "-date", "-rate"}
+
+	if filters.ValidateFilters(v, input.Filters); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	rates, metadata, err := h.rate.GetAll(v, input.Currency, user.ID, input.Filters)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	ratesDTO := make([]*model.ExchangeRateDTO, 0, len(rates))
+	for _, rate := range rates {
+		ratesDTO = append(ratesDTO, rate.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"exchange_rates": ratesDTO, "metadata": metadata}, nil, h.errRsp)
+}
+
+func (h *ExchangeRateHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	rate, err := h.rate.GetByID(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"exchange_rate": rate.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *ExchangeRateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dto model.ExchangeRateDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	rate := dto.ToModel()
+	rate.User = user
+
+	if err := h.rate.Insert(v, rate); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/exchange-rates/%d", rate.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"exchange_rate": rate.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *ExchangeRateHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.ExchangeRateDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	dto.ID = &id
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	rate := dto.ToModel()
+	rate.User = user
+
+	if err := h.rate.Update(v, rate); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"exchange_rate": rate.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *ExchangeRateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.rate.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *ExchangeRateHandler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1_048_576)
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	rates, err := h.rate.Import(v, user.ID, r.Body)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	ratesDTO := make([]*model.ExchangeRateDTO, 0, len(rates))
+	for _, rate := range rates {
+		ratesDTO = append(ratesDTO, rate.ToDTO())
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"exchange_rates": ratesDTO, "imported": len(ratesDTO)}, nil, h.errRsp)
+}
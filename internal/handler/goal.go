@@ -3,11 +3,13 @@ package handler
 import (
 	"financas/internal/model"
 	"financas/internal/model/filters"
+	"financas/internal/model/money"
 	"financas/internal/service"
 	"financas/utils"
 	e "financas/utils/errors"
 	"financas/utils/validator"
 	"net/http"
+	"time"
 )
 
 type GoalHandler struct {
@@ -19,9 +21,17 @@ type GoalHandler struct {
 type GoalHandlerInterface interface {
 	GetAll(w http.ResponseWriter, r *http.Request)
 	GetById(w http.ResponseWriter, r *http.Request)
+	GetShared(w http.ResponseWriter, r *http.Request)
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Simulate(w http.ResponseWriter, r *http.Request)
+	Pause(w http.ResponseWriter, r *http.Request)
+	Resume(w http.ResponseWriter, r *http.Request)
+	Archive(w http.ResponseWriter, r *http.Request)
+	Reopen(w http.ResponseWriter, r *http.Request)
+	ExtendDeadline(w http.ResponseWriter, r *http.Request)
+	GetStatusHistory(w http.ResponseWriter, r *http.Request)
 }
 
 func NewGoalHandler(
@@ -90,7 +100,7 @@ func (h *GoalHandler) GetById(w http.ResponseWriter, r *http.Request) {
 	}
 	v := validator.New()
 	user := h.contextGetUser(r)
-	goal, err := h.goal.GetById(v, id, user.ID)
+	goal, err := h.goal.GetAccessible(v, id, user.ID)
 
 	if err != nil {
 		h.errRsp.HandlerErrorResponse(w, r, err, v)
@@ -107,6 +117,22 @@ func (h *GoalHandler) GetById(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+func (h *GoalHandler) GetShared(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	goals, err := h.goal.GetShared(user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	goalsDTO := make([]*model.GoalDTO, 0, len(goals))
+	for _, g := range goals {
+		goalsDTO = append(goalsDTO, g.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"goals": goalsDTO}, nil, h.errRsp)
+}
+
 func (h *GoalHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var dto model.GoalDTO
 	if err := utils.ReadJSON(w, r, &dto); err != nil {
@@ -118,6 +144,10 @@ func (h *GoalHandler) Create(w http.ResponseWriter, r *http.Request) {
 	user := h.contextGetUser(r)
 	goal := dto.ToModel()
 	goal.User = user
+
+	if goal.Currency == "" {
+		goal.Currency = user.BaseCurrency
+	}
 	if err := h.goal.Create(v, goal); err != nil {
 		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
@@ -134,16 +164,49 @@ func (h *GoalHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *GoalHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
 	var dto model.GoalDTO
 	if err := utils.ReadJSON(w, r, &dto); err != nil {
 		h.errRsp.BadRequestResponse(w, r, err)
 		return
 	}
 
+	dto.ID = &id
+
 	v := validator.New()
 	user := h.contextGetUser(r)
 	goal := dto.ToModel()
 	goal.User = user
+
+	current, err := h.goal.GetById(v, id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	goal.Current = current.Current
+	goal.Status = current.Status
+	if dto.Version == nil {
+		goal.Version = current.Version
+	}
+	if dto.Deadline == nil {
+		goal.Deadline = current.Deadline
+	}
+
+	if goal.Currency == "" {
+		goal.Currency = current.Currency
+	}
+
+	if v.Check(goal.Deadline.Equal(current.Deadline) || current.IsOpen(), "deadline", "must be changed with the extend or reopen action for a goal that is not open"); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+	goal.RefreshStatus(time.Now())
+
 	if err := h.goal.Update(v, goal, user.ID); err != nil {
 		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
@@ -179,3 +242,130 @@ func (h *GoalHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		h.errRsp,
 	)
 }
+
+func (h *GoalHandler) Simulate(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		AnnualRate *float64
+		Monthly    *money.Money
+		Months     int
+	}
+
+	if qs.Has("annual_rate") {
+		rate := utils.ReadFloat(qs, "annual_rate", 0, v)
+		input.AnnualRate = &rate
+	}
+	if qs.Has("monthly_contribution") {
+		monthly := utils.ReadMoney(qs, "monthly_contribution", money.Zero, v)
+		input.Monthly = &monthly
+	}
+	input.Months = utils.ReadInt(qs, "months", 0, v)
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	simulation, err := h.goal.Simulate(v, id, user.ID, input.AnnualRate, input.Monthly, input.Months)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"simulation": simulation}, nil, h.errRsp)
+}
+
+func (h *GoalHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, model.GoalActionPause)
+}
+
+func (h *GoalHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, model.GoalActionResume)
+}
+
+func (h *GoalHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, model.GoalActionArchive)
+}
+
+func (h *GoalHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, model.GoalActionReopen)
+}
+
+func (h *GoalHandler) ExtendDeadline(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, model.GoalActionExtendDeadline)
+}
+
+func (h *GoalHandler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	changes, err := h.goal.GetStatusHistory(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	changesDTO := make([]*model.GoalStatusChangeDTO, 0, len(changes))
+	for _, change := range changes {
+		changesDTO = append(changesDTO, change.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"history": changesDTO}, nil, h.errRsp)
+}
+
+func (h *GoalHandler) changeStatus(w http.ResponseWriter, r *http.Request, action model.GoalAction) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Deadline *string `json:"deadline"`
+		Reason   string  `json:"reason"`
+	}
+
+	if r.ContentLength != 0 {
+		if err := utils.ReadJSON(w, r, &input); err != nil {
+			h.errRsp.BadRequestResponse(w, r, err)
+			return
+		}
+	}
+
+	v := validator.New()
+
+	var deadline *time.Time
+	if input.Deadline != nil {
+		parsed, err := time.Parse(model.GoalDeadlineLayout, *input.Deadline)
+		if err != nil {
+			v.AddError("deadline", "must be a valid date in the format DD/MM/YYYY")
+			h.errRsp.FailedValidationResponse(w, r, v.Errors)
+			return
+		}
+		deadline = &parsed
+	}
+
+	if v.Check(len(input.Reason) <= 500, "reason", "must not be more than 500 bytes long"); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	goal, err := h.goal.ChangeStatus(v, id, user.ID, action, deadline, input.Reason)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"goal": goal.ToDTO()}, nil, h.errRsp)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+)
+
+type GoalMemberHandler struct {
+	member         service.GoalMemberServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type GoalMemberHandlerInterface interface {
+	GetByGoalID(w http.ResponseWriter, r *http.Request)
+	Invite(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	GetContributors(w http.ResponseWriter, r *http.Request)
+	GetInvites(w http.ResponseWriter, r *http.Request)
+	Accept(w http.ResponseWriter, r *http.Request)
+	Decline(w http.ResponseWriter, r *http.Request)
+}
+
+func NewGoalMemberHandler(
+	member service.GoalMemberServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *GoalMemberHandler {
+	return &GoalMemberHandler{
+		member:         member,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *GoalMemberHandler) GetByGoalID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	members, err := h.member.GetByGoalID(v, id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"members": goalMemberDTOs(members)}, nil, h.errRsp)
+}
+
+func (h *GoalMemberHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	var dto model.GoalInviteDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	member, err := h.member.Invite(v, &dto, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/goal_members/%d", member.Goal.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"member": member.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *GoalMemberHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.member.Remove(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *GoalMemberHandler) GetContributors(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	contributors, err := h.member.GetContributors(v, id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"contributors": contributors}, nil, h.errRsp)
+}
+
+func (h *GoalMemberHandler) GetInvites(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+
+	invites, err := h.member.GetInvites(user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"invites": goalMemberDTOs(invites)}, nil, h.errRsp)
+}
+
+func (h *GoalMemberHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, true)
+}
+
+func (h *GoalMemberHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, false)
+}
+
+func (h *GoalMemberHandler) respond(w http.ResponseWriter, r *http.Request, accept bool) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	member, err := h.member.Respond(v, id, user.ID, accept)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"invite": member.ToDTO()}, nil, h.errRsp)
+}
+
+func goalMemberDTOs(members []*model.GoalMember) []*model.GoalMemberDTO {
+	dtos := make([]*model.GoalMemberDTO, 0, len(members))
+	for _, member := range members {
+		dtos = append(dtos, member.ToDTO())
+	}
+	return dtos
+}
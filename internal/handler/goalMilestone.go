@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+)
+
+type GoalMilestoneHandler struct {
+	milestone      service.GoalMilestoneServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type GoalMilestoneHandlerInterface interface {
+	GetByGoalID(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	GetTimeline(w http.ResponseWriter, r *http.Request)
+}
+
+func NewGoalMilestoneHandler(
+	milestone service.GoalMilestoneServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *GoalMilestoneHandler {
+	return &GoalMilestoneHandler{
+		milestone:      milestone,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *GoalMilestoneHandler) GetByGoalID(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	goalID := utils.ReadInt(r.URL.Query(), "goal_id", 0, v)
+	v.Check(goalID > 0, "goal_id", "must be provided")
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	milestones, err := h.milestone.GetByGoalID(int64(goalID), user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	milestonesDTO := make([]*model.GoalMilestoneDTO, 0, len(milestones))
+	for _, milestone := range milestones {
+		milestonesDTO = append(milestonesDTO, milestone.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"milestones": milestonesDTO}, nil, h.errRsp)
+}
+
+func (h *GoalMilestoneHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dto model.GoalMilestoneDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	milestone := dto.ToModel()
+
+	if err := h.milestone.Insert(v, milestone, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/goal_milestones/%d", milestone.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"milestone": milestone.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *GoalMilestoneHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.GoalMilestoneDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	dto.ID = &id
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	milestone := dto.ToModel()
+
+	if err := h.milestone.Update(v, milestone, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"milestone": milestone.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *GoalMilestoneHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.milestone.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *GoalMilestoneHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	events, err := h.milestone.GetTimeline(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	eventsDTO := make([]*model.GoalEventDTO, 0, len(events))
+	for _, event := range events {
+		eventsDTO = append(eventsDTO, event.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"timeline": eventsDTO}, nil, h.errRsp)
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
 	"financas/internal/service"
 	"financas/utils"
 	e "financas/utils/errors"
 	"financas/utils/validator"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 type GoalProgressHandler struct {
@@ -17,8 +21,10 @@ type GoalProgressHandler struct {
 
 type GoalProgressHandlerInterface interface {
 	GetByGoalID(w http.ResponseWriter, r *http.Request)
+	GetMonthlySummary(w http.ResponseWriter, r *http.Request)
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
+	Withdraw(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 }
 
@@ -39,10 +45,43 @@ func (h *GoalProgressHandler) GetByGoalID(w http.ResponseWriter, r *http.Request
 	if !ok {
 		return
 	}
+
+	var input struct {
+		model.GoalProgressFilter
+		filters.Filters
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	if kind := utils.ReadString(qs, "kind", ""); kind != "" {
+		input.Kind = model.GoalProgressKindFromString(kind)
+		v.Check(input.Kind != 0, "kind", "must be CONTRIBUTION or WITHDRAWAL")
+	}
+	input.StartDate = readGoalProgressDate(qs, "start", v)
+	input.EndDate = readGoalProgressDate(qs, "end", v)
+	if qs.Has("min_amount") {
+		minAmount := utils.ReadMoney(qs, "min_amount", money.Zero, v)
+		input.MinAmount = &minAmount
+	}
+	if qs.Has("max_amount") {
+		maxAmount := utils.ReadMoney(qs, "max_amount", money.Zero, v)
+		input.MaxAmount = &maxAmount
+	}
+	input.Filters.Page = utils.ReadInt(qs, "page", 1, v)
+	input.Filters.PageSize = utils.ReadInt(qs, "page_size", 20, v)
+	input.Filters.Sort = utils.ReadString(qs, "sort", "-date")
+	input.Filters.SortSafelist = []string{"id", "date", "amount", "-id", "-date", "-amount"}
+
+	if filters.ValidateFilters(v, input.Filters); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
 	user := h.contextGetUser(r)
-	gPs, err := h.gP.GetGoalProgressIDGoal(user.ID, id)
+	gPs, metadata, err := h.gP.GetAllByGoalID(v, user.ID, id, input.GoalProgressFilter, input.Filters)
 	if err != nil {
-		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
 	}
 
@@ -51,7 +90,35 @@ func (h *GoalProgressHandler) GetByGoalID(w http.ResponseWriter, r *http.Request
 		gPsDTO[i] = gP.ToDTO()
 	}
 
-	respond(w, r, http.StatusOK, utils.Envelope{"goal_progress": gPsDTO}, nil, h.errRsp)
+	respond(w, r, http.StatusOK, utils.Envelope{"goal_progress": gPsDTO, "metadata": metadata}, nil, h.errRsp)
+}
+
+func (h *GoalProgressHandler) GetMonthlySummary(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	startDate := readGoalProgressDate(qs, "start", v)
+	endDate := readGoalProgressDate(qs, "end", v)
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+
+	months, err := h.gP.GetMonthlySummary(v, user.ID, id, startDate, endDate)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"months": months}, nil, h.errRsp)
 }
 
 func (h *GoalProgressHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -78,6 +145,11 @@ func (h *GoalProgressHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *GoalProgressHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
 	var dto model.GoalProgressDTO
 	if err := utils.ReadJSON(w, r, &dto); err != nil {
 		h.errRsp.BadRequestResponse(w, r, err)
@@ -86,10 +158,7 @@ func (h *GoalProgressHandler) Update(w http.ResponseWriter, r *http.Request) {
 	v := validator.New()
 	user := h.contextGetUser(r)
 	gP := dto.ToModel()
-
-	if gP.Goal != nil {
-		gP.Goal.User = user
-	}
+	gP.ID = id
 
 	err := h.gP.Update(v, gP, user.ID)
 	if err != nil {
@@ -100,6 +169,34 @@ func (h *GoalProgressHandler) Update(w http.ResponseWriter, r *http.Request) {
 	respond(w, r, http.StatusOK, utils.Envelope{"goal_progress": gP.ToDTO()}, nil, h.errRsp)
 }
 
+func (h *GoalProgressHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Amount money.Money `json:"amount"`
+		Reason string      `json:"reason"`
+	}
+
+	if err := utils.ReadJSON(w, r, &input); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	gP, err := h.gP.Withdraw(v, id, user.ID, input.Amount, input.Reason)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"goal_progress": gP.ToDTO()}, nil, h.errRsp)
+}
+
 func (h *GoalProgressHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, ok := parseID(w, r, h.errRsp)
 	if !ok {
@@ -116,3 +213,9 @@ func (h *GoalProgressHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
 }
+
+func readGoalProgressDate(qs url.Values, key string, v *validator.Validator) *time.Time {
+	date := utils.ReadDate(qs, key, model.DateLayout)
+	v.Check(date != nil || qs.Get(key) == "", key, "must be a valid date in the format YYYY-MM-DD")
+	return date
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+)
+
+type GoalScheduleHandler struct {
+	schedule       service.GoalScheduleServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type GoalScheduleHandlerInterface interface {
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Pause(w http.ResponseWriter, r *http.Request)
+	Resume(w http.ResponseWriter, r *http.Request)
+}
+
+func NewGoalScheduleHandler(
+	schedule service.GoalScheduleServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *GoalScheduleHandler {
+	return &GoalScheduleHandler{
+		schedule:       schedule,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *GoalScheduleHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+
+	schedules, err := h.schedule.GetAll(user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	schedulesDTO := make([]*model.GoalScheduleDTO, 0, len(schedules))
+	for _, schedule := range schedules {
+		schedulesDTO = append(schedulesDTO, schedule.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"goal_schedules": schedulesDTO}, nil, h.errRsp)
+}
+
+func (h *GoalScheduleHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	schedule, err := h.schedule.GetByID(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"goal_schedule": schedule.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *GoalScheduleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dto model.GoalScheduleDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	schedule := dto.ToModel()
+	schedule.User = user
+
+	if err := h.schedule.Insert(v, schedule); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/goal_schedules/%d", schedule.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"goal_schedule": schedule.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *GoalScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.GoalScheduleDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	dto.ID = &id
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	schedule := dto.ToModel()
+	schedule.User = user
+
+	if err := h.schedule.Update(v, schedule); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"goal_schedule": schedule.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *GoalScheduleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.schedule.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *GoalScheduleHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.toggle(w, r, h.schedule.Pause)
+}
+
+func (h *GoalScheduleHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.toggle(w, r, h.schedule.Resume)
+}
+
+func (h *GoalScheduleHandler) toggle(
+	w http.ResponseWriter,
+	r *http.Request,
+	fn func(id, userID int64) (*model.GoalSchedule, error),
+) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	schedule, err := fn(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"goal_schedule": schedule.ToDTO()}, nil, h.errRsp)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+type GoalTemplateHandler struct {
+	template       service.GoalTemplateServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type GoalTemplateHandlerInterface interface {
+	GetCatalog(w http.ResponseWriter, r *http.Request)
+	Preview(w http.ResponseWriter, r *http.Request)
+	Instantiate(w http.ResponseWriter, r *http.Request)
+}
+
+func NewGoalTemplateHandler(
+	template service.GoalTemplateServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *GoalTemplateHandler {
+	return &GoalTemplateHandler{
+		template:       template,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *GoalTemplateHandler) GetCatalog(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+
+	templates, err := h.template.GetCatalog(user)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"templates": templates}, nil, h.errRsp)
+}
+
+func (h *GoalTemplateHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.parseKind(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	qs := r.URL.Query()
+	input := &model.GoalTemplateInput{}
+
+	if qs.Has("amount") {
+		amount := utils.ReadMoney(qs, "amount", money.Zero, v)
+		input.Amount = &amount
+	}
+	if qs.Has("months") {
+		months := utils.ReadInt(qs, "months", 0, v)
+		input.Months = &months
+	}
+	if qs.Has("deadline") {
+		deadline := utils.ReadString(qs, "deadline", "")
+		input.Deadline = &deadline
+	}
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	preview, err := h.template.Preview(v, user, kind, input)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"preview": preview}, nil, h.errRsp)
+}
+
+func (h *GoalTemplateHandler) Instantiate(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.parseKind(w, r)
+	if !ok {
+		return
+	}
+
+	var input model.GoalTemplateInput
+	if err := utils.ReadJSON(w, r, &input); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	goal, err := h.template.Instantiate(v, user, kind, &input)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/goals/%d", goal.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"goal": goal.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *GoalTemplateHandler) parseKind(w http.ResponseWriter, r *http.Request) (model.GoalTemplateKind, bool) {
+	kind := model.GoalTemplateKindFromString(chi.URLParam(r, "kind"))
+	if kind == 0 {
+		h.errRsp.NotFoundResponse(w, r)
+		return 0, false
+	}
+	return kind, true
+}
@@ -11,30 +11,56 @@ import (
 )
 
 type Handler struct {
-	User         UserHandlerInterface
-	Auth         AuthHandlerInterface
-	Category     CategoryHandlerInterface
-	Report       ReportHandlerInterface
-	Transaction  TransactionHandlerInterface
-	Goal         GoalHandlerInterface
-	GoalProgress GoalProgressHandlerInterface
-	errResp      errors.ErrorResponseInterface
-	Service      *service.Service
+	User          UserHandlerInterface
+	Auth          AuthHandlerInterface
+	Category      CategoryHandlerInterface
+	Report        ReportHandlerInterface
+	Transaction   TransactionHandlerInterface
+	Goal          GoalHandlerInterface
+	GoalProgress  GoalProgressHandlerInterface
+	Admin         AdminHandlerInterface
+	ExchangeRate  ExchangeRateHandlerInterface
+	Statement     StatementHandlerInterface
+	Chart         ChartHandlerInterface
+	Subscription  SubscriptionHandlerInterface
+	Asset         AssetHandlerInterface
+	Loan          LoanHandlerInterface
+	Investment    InvestmentHandlerInterface
+	GoalSchedule  GoalScheduleHandlerInterface
+	GoalMilestone GoalMilestoneHandlerInterface
+	InterestRate  InterestRateHandlerInterface
+	GoalMember    GoalMemberHandlerInterface
+	GoalTemplate  GoalTemplateHandlerInterface
+	errResp       errors.ErrorResponseInterface
+	Service       *service.Service
 }
 
 func NewHandler(db *sql.DB, errResp errors.ErrorResponseInterface, config config.Config, ContextGetUser func(r *http.Request) *model.User) *Handler {
 	service := service.NewService(db, config)
 
 	return &Handler{
-		errResp:      errResp,
-		Service:      service,
-		User:         NewUserHandler(service.User, errResp),
-		Auth:         NewAuthHandler(service.Auth, errResp),
-		Category:     NewCategoryHandler(service.Category, ContextGetUser, errResp),
-		Transaction:  NewTransactionHandler(service.Transaction, errResp, ContextGetUser, service.Category),
-		Report:       NewReportHandler(service.Report, errResp, ContextGetUser),
-		Goal:         NewGoalHandler(service.Goal, errResp, ContextGetUser),
-		GoalProgress: NewGoalProgressHandler(service.GoalProgress, errResp, ContextGetUser),
+		errResp:       errResp,
+		Service:       service,
+		User:          NewUserHandler(service.User, errResp, ContextGetUser),
+		Auth:          NewAuthHandler(service.Auth, errResp),
+		Category:      NewCategoryHandler(service.Category, ContextGetUser, errResp),
+		Transaction:   NewTransactionHandler(service.Transaction, errResp, ContextGetUser, service.Category),
+		Report:        NewReportHandler(service.Report, service.Forecast, service.Anomaly, service.Asset, errResp, ContextGetUser),
+		Goal:          NewGoalHandler(service.Goal, errResp, ContextGetUser),
+		GoalProgress:  NewGoalProgressHandler(service.GoalProgress, errResp, ContextGetUser),
+		Admin:         NewAdminHandler(service.Admin, errResp, ContextGetUser),
+		ExchangeRate:  NewExchangeRateHandler(service.ExchangeRate, errResp, ContextGetUser),
+		Statement:     NewStatementHandler(service.Statement, errResp, ContextGetUser),
+		Chart:         NewChartHandler(service.Chart, errResp, ContextGetUser),
+		Subscription:  NewSubscriptionHandler(service.Subscription, errResp, ContextGetUser),
+		Asset:         NewAssetHandler(service.Asset, errResp, ContextGetUser),
+		Loan:          NewLoanHandler(service.Loan, errResp, ContextGetUser),
+		Investment:    NewInvestmentHandler(service.Investment, errResp, ContextGetUser),
+		GoalSchedule:  NewGoalScheduleHandler(service.GoalSchedule, errResp, ContextGetUser),
+		GoalMilestone: NewGoalMilestoneHandler(service.GoalMilestone, errResp, ContextGetUser),
+		InterestRate:  NewInterestRateHandler(service.InterestRate, errResp, ContextGetUser),
+		GoalMember:    NewGoalMemberHandler(service.GoalMember, errResp, ContextGetUser),
+		GoalTemplate:  NewGoalTemplateHandler(service.GoalTemplate, errResp, ContextGetUser),
 	}
 }
 
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"net/http"
+)
+
+type InterestRateHandler struct {
+	rate           service.InterestRateServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type InterestRateHandlerInterface interface {
+	GetAll(w http.ResponseWriter, r *http.Request)
+	Save(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+func NewInterestRateHandler(
+	rate service.InterestRateServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *InterestRateHandler {
+	return &InterestRateHandler{
+		rate:           rate,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *InterestRateHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+
+	rates, err := h.rate.GetAll(user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	ratesDTO := make([]*model.InterestRateDTO, 0, len(rates))
+	for _, rate := range rates {
+		ratesDTO = append(ratesDTO, rate.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"interest_rates": ratesDTO}, nil, h.errRsp)
+}
+
+func (h *InterestRateHandler) Save(w http.ResponseWriter, r *http.Request) {
+	var dto model.InterestRateDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	rate := dto.ToModel()
+	rate.User = user
+
+	if err := h.rate.Save(v, rate); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"interest_rate": rate.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *InterestRateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.rate.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
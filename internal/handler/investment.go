@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type InvestmentHandler struct {
+	investment     service.InvestmentServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type InvestmentHandlerInterface interface {
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	AddOperation(w http.ResponseWriter, r *http.Request)
+	DeleteOperation(w http.ResponseWriter, r *http.Request)
+	AddPrice(w http.ResponseWriter, r *http.Request)
+	ImportPrices(w http.ResponseWriter, r *http.Request)
+	DeletePrice(w http.ResponseWriter, r *http.Request)
+	GetPositions(w http.ResponseWriter, r *http.Request)
+	GetReturns(w http.ResponseWriter, r *http.Request)
+}
+
+func NewInvestmentHandler(
+	investment service.InvestmentServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *InvestmentHandler {
+	return &InvestmentHandler{
+		investment:     investment,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *InvestmentHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string
+		filters.Filters
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Name = utils.ReadString(qs, "name", "")
+	input.Filters.Page = utils.ReadInt(qs, "page", 1, v)
+	input.Filters.PageSize = utils.ReadInt(qs, "page_size", 20, v)
+	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
+	input.Filters.SortSafelist = []string{"id", "name", "ticker", "kind", "-id", "-name", "-ticker", "-kind"}
+
+	if filters.ValidateFilters(v, input.Filters); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	investments, metadata, err := h.investment.GetAll(v, input.Name, user.ID, input.Filters)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	investmentsDTO := make([]*model.InvestmentDTO, 0, len(investments))
+	for _, investment := range investments {
+		investmentsDTO = append(investmentsDTO, investment.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"investments": investmentsDTO, "metadata": metadata}, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	investment, position, err := h.investment.GetByID(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	operations := make([]*model.InvestmentOperationDTO, 0, len(investment.Operations))
+	for _, operation := range investment.Operations {
+		operations = append(operations, operation.ToDTO())
+	}
+
+	prices := make([]*model.InvestmentPriceDTO, 0, len(investment.Prices))
+	for _, price := range investment.Prices {
+		prices = append(prices, price.ToDTO())
+	}
+
+	dto := investment.ToDTO()
+	dto.Position = position
+
+	respond(w, r, http.StatusOK, utils.Envelope{
+		"investment": dto,
+		"operations": operations,
+		"prices":     prices,
+	}, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dto model.InvestmentDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	investment := dto.ToModel()
+	investment.User = user
+
+	if investment.Currency == "" {
+		investment.Currency = user.BaseCurrency
+	}
+
+	if err := h.investment.Insert(v, investment); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/investments/%d", investment.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"investment": investment.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *InvestmentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.InvestmentDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	dto.ID = &id
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	investment := dto.ToModel()
+	investment.User = user
+
+	if err := h.investment.Update(v, investment); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"investment": investment.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.investment.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) AddOperation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.InvestmentOperationDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	operation := dto.ToModel()
+
+	if err := h.investment.AddOperation(v, id, user.ID, operation); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"operation": operation.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) DeleteOperation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	operationID, err := utils.ReadIntParam(r, "operationID")
+	if err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	if err := h.investment.DeleteOperation(v, operationID, id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) AddPrice(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.InvestmentPriceDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	price := dto.ToModel()
+
+	if err := h.investment.AddPrice(v, id, user.ID, price); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"price": price.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) ImportPrices(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1_048_576)
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	prices, err := h.investment.ImportPrices(v, id, user.ID, r.Body)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	pricesDTO := make([]*model.InvestmentPriceDTO, 0, len(prices))
+	for _, price := range prices {
+		pricesDTO = append(pricesDTO, price.ToDTO())
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"prices": pricesDTO, "imported": len(pricesDTO)}, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) DeletePrice(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	priceID, err := utils.ReadIntParam(r, "priceID")
+	if err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.investment.DeletePrice(priceID, id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) GetPositions(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	date := utils.ReadDate(r.URL.Query(), "date", "2006-01-02")
+
+	positions, err := h.investment.GetPositions(user.ID, date)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"positions": positions}, nil, h.errRsp)
+}
+
+func (h *InvestmentHandler) GetReturns(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		StartDate *time.Time
+		EndDate   *time.Time
+	}
+
+	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
+	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
+
+	returns, err := h.investment.GetReturns(v, user.ID, input.StartDate, input.EndDate)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"portfolio": returns}, nil, h.errRsp)
+}
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+)
+
+type LoanHandler struct {
+	loan           service.LoanServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type LoanHandlerInterface interface {
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Simulate(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	AddPrepayment(w http.ResponseWriter, r *http.Request)
+	DeletePrepayment(w http.ResponseWriter, r *http.Request)
+	PayInstallment(w http.ResponseWriter, r *http.Request)
+	UnpayInstallment(w http.ResponseWriter, r *http.Request)
+}
+
+func NewLoanHandler(
+	loan service.LoanServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *LoanHandler {
+	return &LoanHandler{
+		loan:           loan,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *LoanHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string
+		filters.Filters
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Name = utils.ReadString(qs, "name", "")
+	input.Filters.Page = utils.ReadInt(qs, "page", 1, v)
+	input.Filters.PageSize = utils.ReadInt(qs, "page_size", 20, v)
+	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
+	input.Filters.SortSafelist = []string{"id", "name", "start_date", "principal", "-id", "-name", "-start_date", "-principal"}
+
+	if filters.ValidateFilters(v, input.Filters); !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	loans, metadata, err := h.loan.GetAll(v, input.Name, user.ID, input.Filters)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	loansDTO := make([]*model.LoanDTO, 0, len(loans))
+	for _, loan := range loans {
+		loansDTO = append(loansDTO, loan.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"loans": loansDTO, "metadata": metadata}, nil, h.errRsp)
+}
+
+func (h *LoanHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	schedule, err := h.loan.GetSchedule(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"schedule": schedule}, nil, h.errRsp)
+}
+
+func (h *LoanHandler) Simulate(w http.ResponseWriter, r *http.Request) {
+	var dto model.LoanDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	loan := dto.ToModel()
+	loan.User = user
+
+	if loan.Currency == "" {
+		loan.Currency = user.BaseCurrency
+	}
+
+	schedule, err := h.loan.Simulate(v, loan)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"schedule": schedule}, nil, h.errRsp)
+}
+
+func (h *LoanHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dto model.LoanDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	loan := dto.ToModel()
+	loan.User = user
+
+	if loan.Currency == "" {
+		loan.Currency = user.BaseCurrency
+	}
+
+	if err := h.loan.Insert(v, loan); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	headers := http.Header{"Location": {fmt.Sprintf("/v1/loans/%d", loan.ID)}}
+	respond(w, r, http.StatusCreated, utils.Envelope{"loan": loan.ToDTO()}, headers, h.errRsp)
+}
+
+func (h *LoanHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.LoanDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	dto.ID = &id
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	loan := dto.ToModel()
+	loan.User = user
+
+	if err := h.loan.Update(v, loan); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"loan": loan.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *LoanHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.loan.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *LoanHandler) AddPrepayment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	var dto model.LoanPrepaymentDTO
+	if err := utils.ReadJSON(w, r, &dto); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+	prepayment := dto.ToModel()
+
+	schedule, err := h.loan.AddPrepayment(v, id, user.ID, prepayment)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"prepayment": prepayment.ToDTO(), "schedule": schedule}, nil, h.errRsp)
+}
+
+func (h *LoanHandler) DeletePrepayment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	prepaymentID, err := utils.ReadIntParam(r, "prepaymentID")
+	if err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.loan.DeletePrepayment(prepaymentID, id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *LoanHandler) PayInstallment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	number, err := utils.ReadIntParam(r, "number")
+	if err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input struct {
+		TransactionID int64 `json:"transaction_id"`
+	}
+
+	if err := utils.ReadJSON(w, r, &input); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	if err := h.loan.PayInstallment(v, id, user.ID, int(number), input.TransactionID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	schedule, err := h.loan.GetSchedule(id, user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"schedule": schedule}, nil, h.errRsp)
+}
+
+func (h *LoanHandler) UnpayInstallment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	number, err := utils.ReadIntParam(r, "number")
+	if err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.loan.UnpayInstallment(id, user.ID, int(number)); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
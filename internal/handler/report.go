@@ -2,17 +2,23 @@ package handler
 
 import (
 	"financas/internal/model"
-	"financas/internal/model/filters"
+	"financas/internal/model/money"
 	"financas/internal/service"
+	"financas/internal/xlsx"
 	"financas/utils"
 	e "financas/utils/errors"
 	"financas/utils/validator"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 type ReportHandler struct {
 	report         service.ReportServiceInterface
+	forecast       service.ForecastServiceInterface
+	anomaly        service.AnomalyServiceInterface
+	asset          service.AssetServiceInterface
 	errRsp         e.ErrorResponseInterface
 	contextGetUser func(r *http.Request) *model.User
 }
@@ -22,11 +28,27 @@ type ReportHandlerInterface interface {
 	GetCategoryReportHandler(w http.ResponseWriter, r *http.Request)
 	GetTopCategoriesHandler(w http.ResponseWriter, r *http.Request)
 	GetIncomeVsExpensesHandler(w http.ResponseWriter, r *http.Request)
+	GetCashFlowForecastHandler(w http.ResponseWriter, r *http.Request)
+	GetPeriodComparisonHandler(w http.ResponseWriter, r *http.Request)
+	GetTimeSeriesHandler(w http.ResponseWriter, r *http.Request)
+	ExportXLSXHandler(w http.ResponseWriter, r *http.Request)
+	GetAnomaliesHandler(w http.ResponseWriter, r *http.Request)
+	GetNetWorthHandler(w http.ResponseWriter, r *http.Request)
 }
 
-func NewReportHandler(report service.ReportServiceInterface, errResp e.ErrorResponseInterface, contextGetUser func(r *http.Request) *model.User) *ReportHandler {
+func NewReportHandler(
+	report service.ReportServiceInterface,
+	forecast service.ForecastServiceInterface,
+	anomaly service.AnomalyServiceInterface,
+	asset service.AssetServiceInterface,
+	errResp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *ReportHandler {
 	return &ReportHandler{
 		report:         report,
+		forecast:       forecast,
+		anomaly:        anomaly,
+		asset:          asset,
 		errRsp:         errResp,
 		contextGetUser: contextGetUser,
 	}
@@ -40,23 +62,12 @@ func (h *ReportHandler) GetIncomeVsExpensesHandler(w http.ResponseWriter, r *htt
 	var input struct {
 		StartDate *time.Time
 		EndDate   *time.Time
-		filters.Filters
 	}
 
 	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
 	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
-	input.Filters.Page = utils.ReadInt(qs, "page", 1, v)
-	input.Filters.PageSize = utils.ReadInt(qs, "page_size", 20, v)
-	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
-	input.Filters.SortSafelist = []string{"id", "description", "-id", "-description"}
 
-	filters.ValidateFilters(v, input.Filters)
-	if !v.Valid() {
-		h.errRsp.FailedValidationResponse(w, r, v.Errors)
-		return
-	}
-
-	incomeVsExpenses, err := h.report.GetIncomeVsExpenses(v, user.ID, input.StartDate, input.EndDate, input.Filters)
+	incomeVsExpenses, err := h.report.GetIncomeVsExpenses(v, user.ID, input.StartDate, input.EndDate)
 	if err != nil {
 		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
@@ -75,17 +86,12 @@ func (h *ReportHandler) GetFinancialSummaryHandler(w http.ResponseWriter, r *htt
 	var input struct {
 		StartDate *time.Time
 		EndDate   *time.Time
-		filters.Filters
 	}
 
 	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
 	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
-	input.Filters.Page = 1
-	input.Filters.PageSize = 9999
-	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
-	input.Filters.SortSafelist = []string{"id", "description", "-id", "-description"}
 
-	summary, err := h.report.GetFinancialSummary(v, user.ID, input.StartDate, input.EndDate, input.Filters)
+	summary, err := h.report.GetFinancialSummary(v, user.ID, input.StartDate, input.EndDate)
 	if err != nil {
 		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
@@ -102,17 +108,12 @@ func (h *ReportHandler) GetCategoryReportHandler(w http.ResponseWriter, r *http.
 	var input struct {
 		StartDate *time.Time
 		EndDate   *time.Time
-		filters.Filters
 	}
 
 	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
 	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
-	input.Filters.Page = 1
-	input.Filters.PageSize = 9999
-	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
-	input.Filters.SortSafelist = []string{"id", "description", "-id", "-description"}
 
-	report, err := h.report.GetCategoryReport(v, user.ID, input.StartDate, input.EndDate, input.Filters)
+	report, err := h.report.GetCategoryReport(v, user.ID, input.StartDate, input.EndDate)
 	if err != nil {
 		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
@@ -131,15 +132,10 @@ func (h *ReportHandler) GetTopCategoriesHandler(w http.ResponseWriter, r *http.R
 		EndDate   *time.Time
 		Limit     int
 		typeStr   string
-		filters.Filters
 	}
 
 	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
 	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
-	input.Filters.Page = 1
-	input.Filters.PageSize = 9999
-	input.Filters.Sort = utils.ReadString(qs, "sort", "id")
-	input.Filters.SortSafelist = []string{"id", "description", "-id", "-description"}
 	input.Limit = utils.ReadInt(qs, "limit", 5, v)
 
 	if !v.Valid() {
@@ -150,11 +146,206 @@ func (h *ReportHandler) GetTopCategoriesHandler(w http.ResponseWriter, r *http.R
 	input.typeStr = utils.ReadString(qs, "type", "RECEITA")
 	categoryType := model.TypeCategoriaFromString(input.typeStr)
 
-	topCategories, err := h.report.GetTopCategories(v, user.ID, input.StartDate, input.EndDate, input.Limit, categoryType, input.Filters)
+	topCategories, err := h.report.GetTopCategories(v, user.ID, input.StartDate, input.EndDate, input.Limit, categoryType)
 	if err != nil {
-		h.errRsp.ServerErrorResponse(w, r, err)
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
 	}
 
 	respond(w, r, http.StatusOK, utils.Envelope{"topCategories": topCategories}, nil, h.errRsp)
 }
+
+func (h *ReportHandler) GetCashFlowForecastHandler(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		Days      int
+		Threshold money.Money
+	}
+
+	input.Days = utils.ReadInt(qs, "days", 30, v)
+	input.Threshold = utils.ReadMoney(qs, "threshold", money.Zero, v)
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	forecast, err := h.forecast.GetCashFlowForecast(v, user.ID, input.Days, input.Threshold)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"forecast": forecast}, nil, h.errRsp)
+}
+
+func (h *ReportHandler) GetPeriodComparisonHandler(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		Preset           string
+		StartDate        *time.Time
+		EndDate          *time.Time
+		CompareStartDate *time.Time
+		CompareEndDate   *time.Time
+		Limit            int
+	}
+
+	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
+	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
+	input.CompareStartDate = utils.ReadDate(qs, "compare_start", "2006-01-02")
+	input.CompareEndDate = utils.ReadDate(qs, "compare_end", "2006-01-02")
+	input.Limit = utils.ReadInt(qs, "limit", 5, v)
+
+	defaultPreset := model.ComparisonPresetMonth
+	if input.StartDate != nil || input.EndDate != nil {
+		defaultPreset = model.ComparisonPresetCustom
+	}
+	input.Preset = utils.ReadString(qs, "preset", defaultPreset)
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	comparison, err := h.report.GetPeriodComparison(
+		v,
+		user.ID,
+		input.Preset,
+		input.StartDate,
+		input.EndDate,
+		input.CompareStartDate,
+		input.CompareEndDate,
+		input.Limit,
+	)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"comparison": comparison}, nil, h.errRsp)
+}
+
+func (h *ReportHandler) GetTimeSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		Granularity string
+		StartDate   *time.Time
+		EndDate     *time.Time
+		CategoryID  int
+	}
+
+	input.Granularity = utils.ReadString(qs, "granularity", model.GranularityMonth)
+	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
+	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
+	input.CategoryID = utils.ReadInt(qs, "category_id", 0, v)
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	series, err := h.report.GetTimeSeries(
+		v,
+		user.ID,
+		input.Granularity,
+		input.StartDate,
+		input.EndDate,
+		int64(input.CategoryID),
+	)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"time_series": series}, nil, h.errRsp)
+}
+
+func (h *ReportHandler) ExportXLSXHandler(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		StartDate *time.Time
+		EndDate   *time.Time
+	}
+
+	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
+	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
+
+	file, err := h.report.ExportXLSX(v, user.ID, input.StartDate, input.EndDate)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	filename := fmt.Sprintf("relatorio-%s.xlsx", time.Now().UTC().Format("2006-01-02"))
+
+	w.Header().Set("Content-Type", xlsx.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(file)
+}
+
+func (h *ReportHandler) GetAnomaliesHandler(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		Month     *time.Time
+		Months    int
+		Threshold float64
+	}
+
+	input.Month = utils.ReadDate(qs, "month", model.StatementMonthLayout)
+	input.Months = utils.ReadInt(qs, "months", 6, v)
+	input.Threshold = utils.ReadFloat(qs, "threshold", 3.5, v)
+
+	if !v.Valid() {
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	anomalies, err := h.anomaly.GetAnomalies(v, user.ID, input.Month, input.Months, input.Threshold)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"anomalies": anomalies}, nil, h.errRsp)
+}
+
+func (h *ReportHandler) GetNetWorthHandler(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	var input struct {
+		StartDate   *time.Time
+		EndDate     *time.Time
+		Granularity string
+	}
+
+	input.StartDate = utils.ReadDate(qs, "start", "2006-01-02")
+	input.EndDate = utils.ReadDate(qs, "end", "2006-01-02")
+	input.Granularity = utils.ReadString(qs, "granularity", model.GranularityMonth)
+
+	netWorth, err := h.asset.GetNetWorth(v, user.ID, input.StartDate, input.EndDate, input.Granularity)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"net_worth": netWorth}, nil, h.errRsp)
+}
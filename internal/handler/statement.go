@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/pdf"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+)
+
+type StatementHandler struct {
+	statement      service.StatementServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type StatementHandlerInterface interface {
+	GetAll(w http.ResponseWriter, r *http.Request)
+	Download(w http.ResponseWriter, r *http.Request)
+	Generate(w http.ResponseWriter, r *http.Request)
+}
+
+func NewStatementHandler(
+	statement service.StatementServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *StatementHandler {
+	return &StatementHandler{
+		statement:      statement,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *StatementHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+
+	statements, err := h.statement.GetAll(user.ID)
+	if err != nil {
+		h.errRsp.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	statementsDTO := make([]*model.StatementDTO, 0, len(statements))
+	for _, statement := range statements {
+		statementsDTO = append(statementsDTO, statement.ToDTO())
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"statements": statementsDTO}, nil, h.errRsp)
+}
+
+func (h *StatementHandler) Download(w http.ResponseWriter, r *http.Request) {
+	month, ok := h.parseMonth(w, r)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	v := validator.New()
+
+	file, err := h.statement.GetPDF(v, user.ID, month)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	filename := fmt.Sprintf("extrato-%s.pdf", month.Format(model.StatementMonthLayout))
+
+	w.Header().Set("Content-Type", pdf.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(file)
+}
+
+func (h *StatementHandler) Generate(w http.ResponseWriter, r *http.Request) {
+	month, ok := h.parseMonth(w, r)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	v := validator.New()
+
+	statement, err := h.statement.Generate(v, user.ID, month)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, utils.Envelope{"statement": statement.ToDTO()}, nil, h.errRsp)
+}
+
+func (h *StatementHandler) parseMonth(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	month, err := time.Parse(model.StatementMonthLayout, chi.URLParam(r, "month"))
+	if err != nil {
+		v := validator.New()
+		v.AddError("month", "must be in the format YYYY-MM")
+		h.errRsp.FailedValidationResponse(w, r, v.Errors)
+		return time.Time{}, false
+	}
+	return month, true
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"financas/internal/model"
+	"financas/internal/service"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"net/http"
+)
+
+type SubscriptionHandler struct {
+	subscription   service.SubscriptionServiceInterface
+	errRsp         e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
+}
+
+type SubscriptionHandlerInterface interface {
+	Detect(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+	Confirm(w http.ResponseWriter, r *http.Request)
+	Dismiss(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+func NewSubscriptionHandler(
+	subscription service.SubscriptionServiceInterface,
+	errRsp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscription:   subscription,
+		errRsp:         errRsp,
+		contextGetUser: contextGetUser,
+	}
+}
+
+func (h *SubscriptionHandler) Detect(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+
+	subscriptions, err := h.subscription.Detect(user.ID)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"subscriptions": subscriptionsToDTO(subscriptions)}, nil, h.errRsp)
+}
+
+func (h *SubscriptionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := h.contextGetUser(r)
+	v := validator.New()
+	status := utils.ReadString(r.URL.Query(), "status", "")
+
+	subscriptions, err := h.subscription.GetAll(v, user.ID, status)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"subscriptions": subscriptionsToDTO(subscriptions)}, nil, h.errRsp)
+}
+
+func (h *SubscriptionHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	h.track(w, r, h.subscription.Confirm)
+}
+
+func (h *SubscriptionHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	h.track(w, r, h.subscription.Dismiss)
+}
+
+func (h *SubscriptionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, h.errRsp)
+	if !ok {
+		return
+	}
+
+	user := h.contextGetUser(r)
+	if err := h.subscription.Delete(id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, nil, nil, h.errRsp)
+}
+
+func (h *SubscriptionHandler) track(
+	w http.ResponseWriter,
+	r *http.Request,
+	fn func(v *validator.Validator, userID int64, merchant string) (*model.Subscription, error),
+) {
+	var input struct {
+		Merchant string `json:"merchant"`
+	}
+
+	if err := utils.ReadJSON(w, r, &input); err != nil {
+		h.errRsp.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := h.contextGetUser(r)
+	v := validator.New()
+
+	subscription, err := fn(v, user.ID, input.Merchant)
+	if err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	respond(w, r, http.StatusOK, utils.Envelope{"subscription": subscription.ToDTO()}, nil, h.errRsp)
+}
+
+func subscriptionsToDTO(subscriptions []*model.Subscription) []*model.SubscriptionDTO {
+	dtos := make([]*model.SubscriptionDTO, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		dtos = append(dtos, subscription.ToDTO())
+	}
+	return dtos
+}
@@ -122,6 +122,11 @@ func (h *TransactionHandler) Save(w http.ResponseWriter, r *http.Request) {
 
 	v := validator.New()
 	t := dto.ToModel()
+	user := h.contextGetUser(r)
+
+	if t.Currency == "" {
+		t.Currency = user.BaseCurrency
+	}
 
 	err := h.transaction.Save(v, t)
 	if err != nil {
@@ -129,8 +134,6 @@ func (h *TransactionHandler) Save(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := h.contextGetUser(r)
-
 	h.prepareTransactionForResponse(t, user)
 
 	headers := http.Header{"Location": {fmt.Sprintf("/v1/transactions/%d", t.ID)}}
@@ -165,9 +168,10 @@ func (h *TransactionHandler) DeleteByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	v := validator.New()
 	user := h.contextGetUser(r)
-	if err := h.transaction.Delete(id, user.ID); err != nil {
-		h.errRsp.HandlerErrorResponse(w, r, err, nil)
+	if err := h.transaction.Delete(v, id, user.ID); err != nil {
+		h.errRsp.HandlerErrorResponse(w, r, err, v)
 		return
 	}
 	respond(w, r, http.StatusNoContent, utils.Envelope{"message": "transaction successfully deleted"}, nil, h.errRsp)
@@ -10,19 +10,26 @@ import (
 )
 
 type UserHandler struct {
-	user          service.UserServiceInterface
-	errorResponse e.ErrorResponseInterface
+	user           service.UserServiceInterface
+	errorResponse  e.ErrorResponseInterface
+	contextGetUser func(r *http.Request) *model.User
 }
 
 type UserHandlerInterface interface {
 	ActivateUserHandler(w http.ResponseWriter, r *http.Request)
 	CreateUserHandler(w http.ResponseWriter, r *http.Request)
+	UpdateBaseCurrencyHandler(w http.ResponseWriter, r *http.Request)
 }
 
-func NewUserHandler(userService service.UserServiceInterface, errResp e.ErrorResponseInterface) *UserHandler {
+func NewUserHandler(
+	userService service.UserServiceInterface,
+	errResp e.ErrorResponseInterface,
+	contextGetUser func(r *http.Request) *model.User,
+) *UserHandler {
 	return &UserHandler{
-		user:          userService,
-		errorResponse: errResp,
+		user:           userService,
+		errorResponse:  errResp,
+		contextGetUser: contextGetUser,
 	}
 }
 
@@ -81,3 +88,29 @@ func (h *UserHandler) CreateUserHandler(w http.ResponseWriter, r *http.Request)
 		h.errorResponse.ServerErrorResponse(w, r, err)
 	}
 }
+
+func (h *UserHandler) UpdateBaseCurrencyHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		BaseCurrency string `json:"base_currency"`
+	}
+
+	err := utils.ReadJSON(w, r, &input)
+	if err != nil {
+		h.errorResponse.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	user := h.contextGetUser(r)
+
+	err = h.user.UpdateBaseCurrency(v, user, input.BaseCurrency)
+	if err != nil {
+		h.errorResponse.HandlerErrorResponse(w, r, err, v)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"user": user.ToDTO()}, nil)
+	if err != nil {
+		h.errorResponse.ServerErrorResponse(w, r, err)
+	}
+}
@@ -31,6 +31,7 @@ type Middleware struct {
 	ContextSetUser func(r *http.Request, user *model.User) *http.Request
 	AuthService    service.AuthServiceInterface
 	UserService    service.UserServiceInterface
+	Permission     service.PermissionServiceInterface
 	Config         config.Config
 }
 
@@ -40,6 +41,7 @@ func New(
 	contextSetUser func(r *http.Request, user *model.User) *http.Request,
 	authService service.AuthServiceInterface,
 	userService service.UserServiceInterface,
+	permissionService service.PermissionServiceInterface,
 	Config config.Config,
 ) *Middleware {
 	return &Middleware{
@@ -48,6 +50,7 @@ func New(
 		ContextSetUser: contextSetUser,
 		AuthService:    authService,
 		UserService:    userService,
+		Permission:     permissionService,
 		Config:         Config,
 	}
 }
@@ -57,6 +60,7 @@ type MiddlewareInterface interface {
 	EnableCORS(next http.Handler) http.Handler
 	RequireAuthenticatedUser(next http.Handler) http.Handler
 	RequireActivatedUser(next http.Handler) http.Handler
+	RequirePermission(code string) func(next http.Handler) http.Handler
 	Authenticate(next http.Handler) http.Handler
 	RateLimit(next http.Handler) http.Handler
 	RecoverPanic(next http.Handler) http.Handler
@@ -149,13 +153,35 @@ func (m *Middleware) RequireAuthenticatedUser(next http.Handler) http.Handler {
 func (m *Middleware) RequireActivatedUser(next http.Handler) http.Handler {
 	return m.RequireAuthenticatedUser(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		user := m.ContextGetUser(r)
-		if !user.Activated {
+		if !user.Activated || user.Disabled {
 			m.ErrResp.InactiveAccountResponse(w, r)
 			return
 		}
 		next.ServeHTTP(w, r)
 	}))
 }
+
+func (m *Middleware) RequirePermission(code string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return m.RequireActivatedUser(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := m.ContextGetUser(r)
+
+			permissions, err := m.Permission.GetAllForUser(user.ID)
+			if err != nil {
+				m.ErrResp.ServerErrorResponse(w, r, err)
+				return
+			}
+
+			if !permissions.Include(code) {
+				m.ErrResp.NotPermittedResponse(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
 func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Vary", "Authorization")
@@ -187,6 +213,11 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		if user.Disabled {
+			m.ErrResp.InactiveAccountResponse(w, r)
+			return
+		}
+
 		r = m.ContextSetUser(r, user)
 		next.ServeHTTP(w, r)
 	})
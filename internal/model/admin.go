@@ -0,0 +1,10 @@
+package model
+
+type SystemStats struct {
+	TotalUsers        int            `json:"total_users"`
+	ActivatedUsers    int            `json:"activated_users"`
+	TotalCategories   int            `json:"total_categories"`
+	TotalTransactions int            `json:"total_transactions"`
+	TotalGoals        int            `json:"total_goals"`
+	GoalsByStatus     map[string]int `json:"goals_by_status"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"time"
+)
+
+type AnomalyKind int
+
+const (
+	AnomalyTransaction AnomalyKind = iota + 1
+	AnomalyMonthlyTotal
+)
+
+func (k AnomalyKind) String() string {
+	switch k {
+	case AnomalyTransaction:
+		return "TRANSACTION"
+	case AnomalyMonthlyTotal:
+		return "MONTHLY_TOTAL"
+	default:
+		return ""
+	}
+}
+
+type AnomalyBaseline struct {
+	Median  money.Money `json:"median"`
+	MAD     money.Money `json:"mad"`
+	Samples int         `json:"samples"`
+}
+
+type Anomaly struct {
+	Kind          string          `json:"kind"`
+	Category      *CategoryDTO    `json:"category"`
+	TransactionID *int64          `json:"transaction_id,omitempty"`
+	Description   *string         `json:"description,omitempty"`
+	Date          *time.Time      `json:"date,omitempty"`
+	Amount        money.Money     `json:"amount"`
+	Baseline      AnomalyBaseline `json:"baseline"`
+	Score         *float64        `json:"score"`
+	Explanation   string          `json:"explanation"`
+}
+
+type AnomalyReport struct {
+	Currency       string    `json:"currency"`
+	Month          string    `json:"month"`
+	LookbackMonths int       `json:"lookback_months"`
+	Threshold      float64   `json:"threshold"`
+	Anomalies      []Anomaly `json:"anomalies"`
+}
@@ -0,0 +1,256 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"time"
+)
+
+type AssetClass int
+
+const (
+	AssetClassProperty AssetClass = iota + 1
+	AssetClassVehicle
+	AssetClassInvestment
+	AssetClassCash
+	AssetClassOtherAsset
+	AssetClassLoan
+	AssetClassFinancing
+	AssetClassCreditCard
+	AssetClassOtherLiability
+)
+
+func (c AssetClass) String() string {
+	switch c {
+	case AssetClassProperty:
+		return "PROPERTY"
+	case AssetClassVehicle:
+		return "VEHICLE"
+	case AssetClassInvestment:
+		return "INVESTMENT"
+	case AssetClassCash:
+		return "CASH"
+	case AssetClassOtherAsset:
+		return "OTHER_ASSET"
+	case AssetClassLoan:
+		return "LOAN"
+	case AssetClassFinancing:
+		return "FINANCING"
+	case AssetClassCreditCard:
+		return "CREDIT_CARD"
+	case AssetClassOtherLiability:
+		return "OTHER_LIABILITY"
+	default:
+		return ""
+	}
+}
+
+func AssetClassFromString(s string) AssetClass {
+	for c := AssetClassProperty; c <= AssetClassOtherLiability; c++ {
+		if c.String() == s {
+			return c
+		}
+	}
+	return 0
+}
+
+func (c AssetClass) IsLiability() bool {
+	return c >= AssetClassLoan
+}
+
+func (c AssetClass) Kind() string {
+	if c.IsLiability() {
+		return "LIABILITY"
+	}
+	return "ASSET"
+}
+
+type Asset struct {
+	ID         int64
+	CreatedAt  time.Time
+	User       *User
+	Name       string
+	Class      AssetClass
+	Currency   string
+	Deleted    bool
+	Version    int
+	Valuations []*AssetValuation
+}
+
+type AssetDTO struct {
+	ID           *int64               `json:"asset_id"`
+	Name         *string              `json:"name"`
+	Class        *string              `json:"class"`
+	Kind         *string              `json:"kind"`
+	Currency     *string              `json:"currency"`
+	CurrentValue *money.Money         `json:"current_value,omitempty"`
+	ValuedAt     *string              `json:"valued_at,omitempty"`
+	Valuations   []*AssetValuationDTO `json:"valuations,omitempty"`
+	CreatedAt    *time.Time           `json:"created_at"`
+	Version      *int                 `json:"version"`
+}
+
+type AssetValuation struct {
+	ID        int64
+	AssetID   int64
+	Value     money.Money
+	Date      time.Time
+	CreatedAt time.Time
+	Version   int
+}
+
+type AssetValuationDTO struct {
+	ID        *int64       `json:"valuation_id"`
+	Value     *money.Money `json:"value"`
+	Date      *string      `json:"date"`
+	CreatedAt *time.Time   `json:"created_at"`
+	Version   *int         `json:"version"`
+}
+
+func (a *Asset) Latest() *AssetValuation {
+	var latest *AssetValuation
+	for _, valuation := range a.Valuations {
+		if latest == nil || valuation.Date.After(latest.Date) {
+			latest = valuation
+		}
+	}
+	return latest
+}
+
+func (a *Asset) ValueAt(date time.Time) (*AssetValuation, bool) {
+	var found *AssetValuation
+	for _, valuation := range a.Valuations {
+		if valuation.Date.After(date) {
+			continue
+		}
+		if found == nil || valuation.Date.After(found.Date) {
+			found = valuation
+		}
+	}
+	return found, found != nil
+}
+
+func (a *Asset) ToDTO() *AssetDTO {
+	class := a.Class.String()
+	kind := a.Class.Kind()
+
+	dto := &AssetDTO{
+		ID:        &a.ID,
+		Name:      &a.Name,
+		Class:     &class,
+		Kind:      &kind,
+		Currency:  &a.Currency,
+		CreatedAt: &a.CreatedAt,
+		Version:   &a.Version,
+	}
+
+	if latest := a.Latest(); latest != nil {
+		date := latest.Date.Format(DateLayout)
+		dto.CurrentValue = &latest.Value
+		dto.ValuedAt = &date
+	}
+
+	return dto
+}
+
+func (m *AssetDTO) ToModel() *Asset {
+	asset := &Asset{}
+
+	if m.ID != nil {
+		asset.ID = *m.ID
+	}
+	if m.Name != nil {
+		asset.Name = *m.Name
+	}
+	if m.Class != nil {
+		asset.Class = AssetClassFromString(*m.Class)
+	}
+	if m.Currency != nil {
+		asset.Currency = NormalizeCurrency(*m.Currency)
+	}
+	if m.Version != nil {
+		asset.Version = *m.Version
+	}
+	return asset
+}
+
+func (a *Asset) ValidateAsset(v *validator.Validator) {
+	v.Check(a.Name != "", "name", "must be provided")
+	v.Check(len(a.Name) <= 255, "name", "must not be more than 255 bytes long")
+	v.Check(a.Class != 0, "class", "invalid asset class")
+	ValidateCurrency(v, "currency", a.Currency)
+}
+
+func (a *AssetValuation) ToDTO() *AssetValuationDTO {
+	date := a.Date.Format(DateLayout)
+
+	return &AssetValuationDTO{
+		ID:        &a.ID,
+		Value:     &a.Value,
+		Date:      &date,
+		CreatedAt: &a.CreatedAt,
+		Version:   &a.Version,
+	}
+}
+
+func (m *AssetValuationDTO) ToModel() *AssetValuation {
+	valuation := &AssetValuation{}
+
+	if m.ID != nil {
+		valuation.ID = *m.ID
+	}
+	if m.Value != nil {
+		valuation.Value = *m.Value
+	}
+	if m.Date != nil {
+		parsedTime, err := time.Parse(DateLayout, *m.Date)
+		if err == nil {
+			valuation.Date = parsedTime
+		}
+	}
+	if m.Version != nil {
+		valuation.Version = *m.Version
+	}
+	return valuation
+}
+
+func (a *AssetValuation) ValidateAssetValuation(v *validator.Validator) {
+	v.Check(!a.Value.IsNegative(), "value", "must not be negative")
+	v.Check(!a.Date.IsZero(), "date", "must be a valid date in the format YYYY-MM-DD")
+	v.Check(!a.Date.After(time.Now().UTC()), "date", "must not be in the future")
+}
+
+type NetWorthItem struct {
+	AssetID int64       `json:"asset_id"`
+	Name    string      `json:"name"`
+	Value   money.Money `json:"value"`
+	Date    string      `json:"valued_at"`
+}
+
+type NetWorthClass struct {
+	Class      string         `json:"class"`
+	Kind       string         `json:"kind"`
+	Total      money.Money    `json:"total"`
+	Percentage float64        `json:"percentage"`
+	Items      []NetWorthItem `json:"items"`
+}
+
+type NetWorthPoint struct {
+	Date               string      `json:"date"`
+	TransactionBalance money.Money `json:"transaction_balance"`
+	Assets             money.Money `json:"assets"`
+	Liabilities        money.Money `json:"liabilities"`
+	NetWorth           money.Money `json:"net_worth"`
+}
+
+type NetWorthReport struct {
+	Currency           string          `json:"currency"`
+	Date               string          `json:"date"`
+	Granularity        string          `json:"granularity"`
+	TransactionBalance money.Money     `json:"transaction_balance"`
+	TotalAssets        money.Money     `json:"total_assets"`
+	TotalLiabilities   money.Money     `json:"total_liabilities"`
+	NetWorth           money.Money     `json:"net_worth"`
+	Breakdown          []NetWorthClass `json:"breakdown"`
+	History            []NetWorthPoint `json:"history"`
+}
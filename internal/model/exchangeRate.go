@@ -0,0 +1,91 @@
+package model
+
+import (
+	"financas/utils/validator"
+	"strings"
+	"time"
+)
+
+const DefaultCurrency = "BRL"
+
+var SupportedCurrencies = []string{"BRL", "USD", "EUR"}
+
+const ExchangeRateDateLayout = DateLayout
+
+type ExchangeRate struct {
+	ID           int64
+	User         *User
+	FromCurrency string
+	ToCurrency   string
+	Rate         float64
+	Date         time.Time
+	CreatedAt    time.Time
+	Version      int
+}
+
+type ExchangeRateDTO struct {
+	ID           *int64     `json:"exchange_rate_id"`
+	FromCurrency *string    `json:"from_currency"`
+	ToCurrency   *string    `json:"to_currency"`
+	Rate         *float64   `json:"rate"`
+	Date         *string    `json:"date"`
+	CreatedAt    *time.Time `json:"created_at"`
+	Version      *int       `json:"version"`
+}
+
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func ValidateCurrency(v *validator.Validator, key, code string) {
+	v.Check(code != "", key, "must be provided")
+	v.Check(validator.In(code, SupportedCurrencies...), key, "unsupported currency")
+}
+
+func (r *ExchangeRate) ToDTO() *ExchangeRateDTO {
+	dto := &ExchangeRateDTO{}
+	dto.ID = &r.ID
+	dto.FromCurrency = &r.FromCurrency
+	dto.ToCurrency = &r.ToCurrency
+	dto.Rate = &r.Rate
+	dateStr := r.Date.Format(ExchangeRateDateLayout)
+	dto.Date = &dateStr
+	dto.CreatedAt = &r.CreatedAt
+	dto.Version = &r.Version
+	return dto
+}
+
+func (m *ExchangeRateDTO) ToModel() *ExchangeRate {
+	rate := &ExchangeRate{}
+
+	if m.ID != nil {
+		rate.ID = *m.ID
+	}
+	if m.FromCurrency != nil {
+		rate.FromCurrency = NormalizeCurrency(*m.FromCurrency)
+	}
+	if m.ToCurrency != nil {
+		rate.ToCurrency = NormalizeCurrency(*m.ToCurrency)
+	}
+	if m.Rate != nil {
+		rate.Rate = *m.Rate
+	}
+	if m.Date != nil {
+		parsedTime, err := time.Parse(ExchangeRateDateLayout, *m.Date)
+		if err == nil {
+			rate.Date = parsedTime
+		}
+	}
+	if m.Version != nil {
+		rate.Version = *m.Version
+	}
+	return rate
+}
+
+func (r *ExchangeRate) ValidateExchangeRate(v *validator.Validator) {
+	ValidateCurrency(v, "from_currency", r.FromCurrency)
+	ValidateCurrency(v, "to_currency", r.ToCurrency)
+	v.Check(r.FromCurrency != r.ToCurrency, "to_currency", "must be different from from_currency")
+	v.Check(r.Rate > 0, "rate", "must be positive")
+	v.Check(!r.Date.IsZero(), "date", "must be a valid date in the format YYYY-MM-DD")
+}
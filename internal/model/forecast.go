@@ -0,0 +1,63 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"time"
+)
+
+type ForecastEventType int
+
+const (
+	ForecastEventRecurringIncome ForecastEventType = iota + 1
+	ForecastEventRecurringExpense
+	ForecastEventGoalInstallment
+)
+
+func (t ForecastEventType) String() string {
+	switch t {
+	case ForecastEventRecurringIncome:
+		return "RECURRING_INCOME"
+	case ForecastEventRecurringExpense:
+		return "RECURRING_EXPENSE"
+	case ForecastEventGoalInstallment:
+		return "GOAL_INSTALLMENT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+type RecurringTransaction struct {
+	Description string       `json:"description"`
+	Category    *CategoryDTO `json:"category"`
+	Amount      money.Money  `json:"amount"`
+	DayOfMonth  int          `json:"day_of_month"`
+	Occurrences int          `json:"occurrences"`
+	LastDate    time.Time    `json:"last_date"`
+}
+
+type ForecastEvent struct {
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Amount      money.Money `json:"amount"`
+	GoalID      *int64      `json:"goal_id,omitempty"`
+}
+
+type ForecastDay struct {
+	Date           string          `json:"date"`
+	Income         money.Money     `json:"income"`
+	Expenses       money.Money     `json:"expenses"`
+	Balance        money.Money     `json:"balance"`
+	BelowThreshold bool            `json:"below_threshold"`
+	Events         []ForecastEvent `json:"events"`
+}
+
+type CashFlowForecast struct {
+	Currency              string                 `json:"currency"`
+	StartingBalance       money.Money            `json:"starting_balance"`
+	Threshold             money.Money            `json:"threshold"`
+	LowestBalance         money.Money            `json:"lowest_balance"`
+	LowestBalanceDate     string                 `json:"lowest_balance_date"`
+	AlertDates            []string               `json:"alert_dates"`
+	RecurringTransactions []RecurringTransaction `json:"recurring_transactions"`
+	Days                  []ForecastDay          `json:"days"`
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"financas/internal/model/money"
 	"financas/utils/validator"
 	"time"
 )
@@ -12,8 +13,39 @@ const (
 	GoalStatusInProgress
 	GoalStatusFinished
 	GoalStatusFailed
+	GoalStatusPaused
+	GoalStatusArchived
 )
 
+type GoalProgressKind int
+
+const (
+	GoalProgressContribution GoalProgressKind = iota + 1
+	GoalProgressWithdrawal
+)
+
+func (k GoalProgressKind) String() string {
+	switch k {
+	case GoalProgressContribution:
+		return "CONTRIBUTION"
+	case GoalProgressWithdrawal:
+		return "WITHDRAWAL"
+	default:
+		return ""
+	}
+}
+
+func GoalProgressKindFromString(s string) GoalProgressKind {
+	switch s {
+	case "CONTRIBUTION":
+		return GoalProgressContribution
+	case "WITHDRAWAL":
+		return GoalProgressWithdrawal
+	default:
+		return 0
+	}
+}
+
 type Goal struct {
 	ID           int64
 	Name         string
@@ -21,13 +53,16 @@ type Goal struct {
 	Color        string
 	User         *User
 	Deadline     time.Time
-	Amount       float64
-	Current      float64
+	Amount       money.Money
+	Current      money.Money
+	Currency     string
 	Status       GoalStatus
+	AnnualRate   *float64
 	Version      int
 	CreatedAt    time.Time
 	Deleted      bool
 	Installments *Installments
+	Forecast     *GoalForecast
 }
 type GoalDTO struct {
 	ID           *int64        `json:"goal_id"`
@@ -36,37 +71,89 @@ type GoalDTO struct {
 	Color        *string       `json:"color"`
 	User         *UserDTO      `json:"user"`
 	Deadline     *string       `json:"deadline"`
-	Amount       *float64      `json:"amount"`
-	Current      *float64      `json:"current"`
+	Amount       *money.Money  `json:"amount"`
+	Current      *money.Money  `json:"current"`
+	Currency     *string       `json:"currency"`
 	Status       *string       `json:"status"`
+	AnnualRate   *float64      `json:"annual_rate,omitempty"`
 	Version      *int          `json:"version"`
 	CreatedAt    *time.Time    `json:"created_at"`
 	Installments *Installments `json:"Installments"`
+	Forecast     *GoalForecast `json:"forecast,omitempty"`
 }
 
 type Installments struct {
-	Amount   float64
+	Amount   money.Money
 	Quantity int
 }
 
+const (
+	GoalForecastCompleted = "COMPLETED"
+	GoalForecastOnTrack   = "ON_TRACK"
+	GoalForecastAtRisk    = "AT_RISK"
+	GoalForecastOffTrack  = "OFF_TRACK"
+)
+
+type GoalForecast struct {
+	Status              string      `json:"status"`
+	MonthlyRate         money.Money `json:"monthly_rate"`
+	RequiredMonthly     money.Money `json:"required_monthly"`
+	Pace                float64     `json:"pace"`
+	ProjectedCompletion *string     `json:"projected_completion,omitempty"`
+}
+
+type ContributionTotal struct {
+	First  time.Time
+	Amount money.Money
+}
+
 type GoalProgress struct {
-	ID        int64
-	Goal      *Goal
-	Amount    float64
-	Date      time.Time
-	Version   *int
-	CreatedAt *time.Time
-	Deleted   bool
+	ID                 int64
+	Goal               *Goal
+	User               *User
+	Kind               GoalProgressKind
+	Amount             money.Money
+	Reason             string
+	Date               time.Time
+	Version            *int
+	CreatedAt          *time.Time
+	Deleted            bool
+	Transaction        *Transaction
+	TransactionCreated bool
 }
 
 type GoalProgressDTO struct {
-	ID        *int64     `json:"goal_progress_id"`
-	Goal      *GoalDTO   `json:"goal"`
-	Amount    *float64   `json:"amount"`
-	Date      *time.Time `json:"date"`
-	Version   *int       `json:"version"`
-	CreatedAt *time.Time `json:"created_at"`
-	Deleted   *bool      `json:"deleted"`
+	ID                *int64       `json:"goal_progress_id"`
+	Goal              *GoalDTO     `json:"goal"`
+	UserID            *int64       `json:"user_id,omitempty"`
+	UserName          *string      `json:"user_name,omitempty"`
+	Kind              *string      `json:"kind"`
+	Amount            *money.Money `json:"amount"`
+	Reason            *string      `json:"reason,omitempty"`
+	Date              *time.Time   `json:"date"`
+	TransactionID     *int64       `json:"transaction_id,omitempty"`
+	CreateTransaction *bool        `json:"create_transaction,omitempty"`
+	CategoryID        *int64       `json:"category_id,omitempty"`
+	Version           *int         `json:"version"`
+	CreatedAt         *time.Time   `json:"created_at"`
+	Deleted           *bool        `json:"deleted"`
+}
+
+type GoalProgressFilter struct {
+	Kind      GoalProgressKind
+	StartDate *time.Time
+	EndDate   *time.Time
+	MinAmount *money.Money
+	MaxAmount *money.Money
+}
+
+type GoalProgressMonth struct {
+	Month         string      `json:"month"`
+	Contributions money.Money `json:"contributions"`
+	Withdrawals   money.Money `json:"withdrawals"`
+	Net           money.Money `json:"net"`
+	Entries       int         `json:"entries"`
+	Balance       money.Money `json:"balance"`
 }
 
 func (s GoalStatus) String() string {
@@ -79,6 +166,10 @@ func (s GoalStatus) String() string {
 		return "FINISHED"
 	case GoalStatusFailed:
 		return "FAILED"
+	case GoalStatusPaused:
+		return "PAUSED"
+	case GoalStatusArchived:
+		return "ARCHIVED"
 	default:
 		return "UNKNOWN"
 	}
@@ -94,6 +185,10 @@ func GoalStatusFromString(str string) GoalStatus {
 		return GoalStatusFinished
 	case "FAILED":
 		return GoalStatusFailed
+	case "PAUSED":
+		return GoalStatusPaused
+	case "ARCHIVED":
+		return GoalStatusArchived
 	default:
 		return 0
 	}
@@ -110,11 +205,14 @@ func (g *Goal) ToDTO() *GoalDTO {
 	goal.Deadline = &deadlineStr
 	goal.Amount = &g.Amount
 	goal.Current = &g.Current
+	goal.Currency = &g.Currency
 	statusStr := g.Status.String()
 	goal.Status = &statusStr
+	goal.AnnualRate = g.AnnualRate
 	goal.Version = &g.Version
 	goal.CreatedAt = &g.CreatedAt
 	goal.Installments = g.Installments
+	goal.Forecast = g.Forecast
 	return goal
 }
 
@@ -149,9 +247,18 @@ func (m *GoalDTO) ToModel() *Goal {
 	if m.Current != nil {
 		goal.Current = *m.Current
 	}
+	if m.Currency != nil {
+		goal.Currency = NormalizeCurrency(*m.Currency)
+	}
 	if m.Status != nil {
 		goal.Status = GoalStatusFromString(*m.Status)
 	}
+	if m.AnnualRate != nil {
+		goal.AnnualRate = m.AnnualRate
+	}
+	if m.Version != nil {
+		goal.Version = *m.Version
+	}
 	return goal
 }
 
@@ -159,19 +266,43 @@ func (g *GoalProgress) ToDTO() *GoalProgressDTO {
 	goalProgress := &GoalProgressDTO{}
 	goalProgress.ID = &g.ID
 	goalProgress.Goal = g.Goal.ToDTO()
+	if g.User != nil {
+		goalProgress.UserID = &g.User.ID
+		if g.User.Name != "" {
+			goalProgress.UserName = &g.User.Name
+		}
+	}
+	kind := g.Kind.String()
+	goalProgress.Kind = &kind
 	goalProgress.Amount = &g.Amount
+	if g.Reason != "" {
+		goalProgress.Reason = &g.Reason
+	}
 	goalProgress.Date = &g.Date
 	goalProgress.Version = g.Version
 	goalProgress.CreatedAt = g.CreatedAt
 	goalProgress.Deleted = &g.Deleted
+	if g.Transaction != nil {
+		goalProgress.TransactionID = &g.Transaction.ID
+		goalProgress.CreateTransaction = &g.TransactionCreated
+		if g.Transaction.Category != nil && g.Transaction.Category.ID != 0 {
+			goalProgress.CategoryID = &g.Transaction.Category.ID
+		}
+	}
 	return goalProgress
 }
 
 func (g *GoalProgressDTO) ToModel() *GoalProgress {
-	goalProgress := &GoalProgress{}
+	goalProgress := &GoalProgress{Kind: GoalProgressContribution}
 	if g.ID != nil {
 		goalProgress.ID = *g.ID
 	}
+	if g.Kind != nil {
+		goalProgress.Kind = GoalProgressKindFromString(*g.Kind)
+	}
+	if g.Reason != nil {
+		goalProgress.Reason = *g.Reason
+	}
 	if g.Goal != nil {
 		goalProgress.Goal = g.Goal.ToModel()
 	}
@@ -190,6 +321,15 @@ func (g *GoalProgressDTO) ToModel() *GoalProgress {
 	if g.CreatedAt != nil {
 		goalProgress.CreatedAt = g.CreatedAt
 	}
+	if g.CreateTransaction != nil && *g.CreateTransaction {
+		goalProgress.TransactionCreated = true
+		goalProgress.Transaction = &Transaction{Category: &Category{}}
+		if g.CategoryID != nil {
+			goalProgress.Transaction.Category.ID = *g.CategoryID
+		}
+	} else if g.TransactionID != nil {
+		goalProgress.Transaction = &Transaction{ID: *g.TransactionID}
+	}
 	return goalProgress
 }
 
@@ -202,9 +342,69 @@ func (g *Goal) ValidateGoal(v *validator.Validator) {
 	v.Check(g.Status.String() != "Unknown", "status", "invalid status value")
 	v.Check(g.Color != "", "color", "must be provided")
 	v.Check(g.Amount != 0, "amount", "must be provided")
+	if g.AnnualRate != nil {
+		v.Check(*g.AnnualRate >= 0 && *g.AnnualRate <= 100, "annual_rate", "must be between 0 and 100")
+	}
+	ValidateCurrency(v, "currency", g.Currency)
+}
+
+func (f GoalProgressFilter) Validate(v *validator.Validator) {
+	if f.StartDate != nil && f.EndDate != nil {
+		v.Check(!f.EndDate.Before(*f.StartDate), "end", "must not be before start")
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil {
+		v.Check(*f.MinAmount <= *f.MaxAmount, "max_amount", "must not be less than min_amount")
+	}
 }
 
 func (g *GoalProgress) ValidateGoalProgress(v *validator.Validator) {
 	v.Check(g.Amount != 0, "amount", "must be provided")
 	v.Check(g.Goal != nil, "goal", "must be provided")
+	v.Check(len(g.Reason) <= 500, "reason", "must not be more than 500 bytes long")
+
+	switch g.Kind {
+	case GoalProgressContribution:
+		v.Check(!g.Amount.IsNegative(), "amount", "must be positive for a contribution, use a withdrawal instead")
+	case GoalProgressWithdrawal:
+		v.Check(!g.Amount.IsPositive(), "amount", "must be negative for a withdrawal")
+		v.Check(g.Transaction == nil, "transaction_id", "must not be provided for a withdrawal")
+	default:
+		v.AddError("kind", "must be CONTRIBUTION or WITHDRAWAL")
+	}
+
+	if g.TransactionCreated && g.Transaction != nil && g.Transaction.ID == 0 {
+		v.Check(g.Amount.IsPositive(), "amount", "must be positive to create a transaction")
+		v.Check(g.Transaction.Category.ID > 0, "category_id", "must be provided to create a transaction")
+	}
+}
+
+const (
+	SimulationRateQuery   = "QUERY"
+	SimulationRateGoal    = "GOAL"
+	SimulationRateHistory = "HISTORY"
+	SimulationRateNone    = "NONE"
+)
+
+type GoalSimulationMonth struct {
+	Month        string      `json:"month"`
+	Opening      money.Money `json:"opening"`
+	Contribution money.Money `json:"contribution"`
+	Interest     money.Money `json:"interest"`
+	Closing      money.Money `json:"closing"`
+}
+
+type GoalSimulation struct {
+	GoalID              int64                 `json:"goal_id"`
+	Currency            string                `json:"currency"`
+	Target              money.Money           `json:"target"`
+	AnnualRate          float64               `json:"annual_rate"`
+	RateSource          string                `json:"rate_source"`
+	Months              int                   `json:"months"`
+	MonthlyContribution money.Money           `json:"monthly_contribution"`
+	RequiredMonthly     money.Money           `json:"required_monthly"`
+	TotalContributions  money.Money           `json:"total_contributions"`
+	TotalInterest       money.Money           `json:"total_interest"`
+	FinalBalance        money.Money           `json:"final_balance"`
+	ReachedIn           *string               `json:"reached_in,omitempty"`
+	Schedule            []GoalSimulationMonth `json:"schedule"`
 }
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"financas/utils/validator"
+	"fmt"
+	"time"
+)
+
+const GoalDeadlineLayout = "02/01/2006"
+
+type GoalAction int
+
+const (
+	GoalActionPause GoalAction = iota + 1
+	GoalActionResume
+	GoalActionArchive
+	GoalActionReopen
+	GoalActionExtendDeadline
+	GoalActionAutomatic
+)
+
+func (a GoalAction) String() string {
+	switch a {
+	case GoalActionPause:
+		return "PAUSE"
+	case GoalActionResume:
+		return "RESUME"
+	case GoalActionArchive:
+		return "ARCHIVE"
+	case GoalActionReopen:
+		return "REOPEN"
+	case GoalActionExtendDeadline:
+		return "EXTEND_DEADLINE"
+	case GoalActionAutomatic:
+		return "AUTOMATIC"
+	default:
+		return ""
+	}
+}
+
+var goalTransitions = map[GoalAction][]GoalStatus{
+	GoalActionPause:          {GoalStatusPending, GoalStatusInProgress},
+	GoalActionResume:         {GoalStatusPaused},
+	GoalActionArchive:        {GoalStatusPending, GoalStatusInProgress, GoalStatusFinished, GoalStatusFailed, GoalStatusPaused},
+	GoalActionReopen:         {GoalStatusFinished, GoalStatusFailed, GoalStatusArchived},
+	GoalActionExtendDeadline: {GoalStatusPending, GoalStatusInProgress, GoalStatusPaused, GoalStatusFailed},
+}
+
+type GoalStatusChange struct {
+	ID               int64
+	GoalID           int64
+	From             GoalStatus
+	To               GoalStatus
+	Action           GoalAction
+	ChangedBy        *int64
+	Reason           string
+	PreviousDeadline *time.Time
+	NewDeadline      *time.Time
+	CreatedAt        time.Time
+}
+
+type GoalStatusChangeDTO struct {
+	ID               *int64     `json:"change_id"`
+	From             *string    `json:"from_status"`
+	To               *string    `json:"to_status"`
+	Action           *string    `json:"action"`
+	ChangedBy        *int64     `json:"changed_by,omitempty"`
+	Reason           *string    `json:"reason,omitempty"`
+	PreviousDeadline *string    `json:"previous_deadline,omitempty"`
+	NewDeadline      *string    `json:"new_deadline,omitempty"`
+	CreatedAt        *time.Time `json:"created_at"`
+}
+
+func (g *Goal) IsOpen() bool {
+	return g.Status == GoalStatusPending || g.Status == GoalStatusInProgress
+}
+
+func (g *Goal) ActiveStatus() GoalStatus {
+	switch {
+	case g.Current >= g.Amount:
+		return GoalStatusFinished
+	case g.Current > 0:
+		return GoalStatusInProgress
+	default:
+		return GoalStatusPending
+	}
+}
+
+func (g *Goal) RefreshStatus(now time.Time) {
+	if !g.IsOpen() && g.Status != GoalStatusFinished {
+		return
+	}
+
+	if now.After(g.Deadline) && g.Current < g.Amount {
+		g.Status = GoalStatusFailed
+		return
+	}
+
+	g.Status = g.ActiveStatus()
+}
+
+func (g *Goal) ApplyAction(v *validator.Validator, action GoalAction, deadline *time.Time, now time.Time) {
+	allowed := false
+	for _, status := range goalTransitions[action] {
+		if g.Status == status {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		v.AddError("status", fmt.Sprintf("%s is not allowed for a goal with status %s", action, g.Status))
+		return
+	}
+
+	switch action {
+	case GoalActionPause:
+		g.Status = GoalStatusPaused
+	case GoalActionArchive:
+		g.Status = GoalStatusArchived
+	case GoalActionResume, GoalActionReopen:
+		if deadline != nil {
+			v.Check(deadline.After(now), "deadline", "must be in the future")
+			g.Deadline = *deadline
+		}
+		v.Check(g.Deadline.After(now), "deadline", "has passed and must be extended")
+		v.Check(g.ActiveStatus() != GoalStatusFinished || action == GoalActionResume, "amount", "has already been reached")
+		g.Status = g.ActiveStatus()
+	case GoalActionExtendDeadline:
+		if deadline == nil {
+			v.AddError("deadline", "must be provided")
+			return
+		}
+		v.Check(deadline.After(g.Deadline), "deadline", "must be after the current deadline")
+		v.Check(deadline.After(now), "deadline", "must be in the future")
+		g.Deadline = *deadline
+		if g.Status == GoalStatusFailed {
+			g.Status = g.ActiveStatus()
+		}
+	}
+}
+
+func (c *GoalStatusChange) ToDTO() *GoalStatusChangeDTO {
+	from := c.From.String()
+	to := c.To.String()
+	action := c.Action.String()
+
+	dto := &GoalStatusChangeDTO{
+		ID:        &c.ID,
+		From:      &from,
+		To:        &to,
+		Action:    &action,
+		ChangedBy: c.ChangedBy,
+		CreatedAt: &c.CreatedAt,
+	}
+
+	if c.Reason != "" {
+		dto.Reason = &c.Reason
+	}
+	if c.PreviousDeadline != nil {
+		previous := c.PreviousDeadline.Format(GoalDeadlineLayout)
+		dto.PreviousDeadline = &previous
+	}
+	if c.NewDeadline != nil {
+		next := c.NewDeadline.Format(GoalDeadlineLayout)
+		dto.NewDeadline = &next
+	}
+
+	return dto
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"time"
+)
+
+type GoalMemberStatus int
+
+const (
+	GoalMemberPending GoalMemberStatus = iota + 1
+	GoalMemberAccepted
+	GoalMemberDeclined
+)
+
+func (s GoalMemberStatus) String() string {
+	switch s {
+	case GoalMemberPending:
+		return "PENDING"
+	case GoalMemberAccepted:
+		return "ACCEPTED"
+	case GoalMemberDeclined:
+		return "DECLINED"
+	default:
+		return ""
+	}
+}
+
+type GoalMember struct {
+	ID          int64
+	Goal        *Goal
+	User        *User
+	InvitedBy   int64
+	Status      GoalMemberStatus
+	CreatedAt   time.Time
+	RespondedAt *time.Time
+}
+
+type GoalMemberDTO struct {
+	ID          *int64     `json:"member_id"`
+	GoalID      *int64     `json:"goal_id"`
+	GoalName    *string    `json:"goal_name,omitempty"`
+	UserID      *int64     `json:"user_id"`
+	Name        *string    `json:"name"`
+	Email       *string    `json:"email"`
+	InvitedBy   *int64     `json:"invited_by"`
+	Status      *string    `json:"status"`
+	CreatedAt   *time.Time `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+type GoalInviteDTO struct {
+	GoalID *int64  `json:"goal_id"`
+	Email  *string `json:"email"`
+}
+
+type GoalContributor struct {
+	UserID     int64       `json:"user_id"`
+	Name       string      `json:"name"`
+	Owner      bool        `json:"owner"`
+	Entries    int         `json:"entries"`
+	Total      money.Money `json:"total"`
+	Percentage float64     `json:"percentage"`
+}
+
+func (m *GoalMember) ToDTO() *GoalMemberDTO {
+	status := m.Status.String()
+
+	dto := &GoalMemberDTO{
+		ID:          &m.ID,
+		GoalID:      &m.Goal.ID,
+		UserID:      &m.User.ID,
+		Name:        &m.User.Name,
+		Email:       &m.User.Email,
+		InvitedBy:   &m.InvitedBy,
+		Status:      &status,
+		CreatedAt:   &m.CreatedAt,
+		RespondedAt: m.RespondedAt,
+	}
+
+	if m.Goal.Name != "" {
+		dto.GoalName = &m.Goal.Name
+	}
+
+	return dto
+}
+
+func (i *GoalInviteDTO) Validate(v *validator.Validator) {
+	v.Check(i.GoalID != nil && *i.GoalID > 0, "goal_id", "must be provided")
+	if v.Check(i.Email != nil, "email", "must be provided"); i.Email != nil {
+		ValidateEmail(v, *i.Email)
+	}
+}
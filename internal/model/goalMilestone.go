@@ -0,0 +1,211 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"time"
+)
+
+type MilestoneKind int
+
+const (
+	MilestoneAmount MilestoneKind = iota + 1
+	MilestonePercentage
+)
+
+func (k MilestoneKind) String() string {
+	switch k {
+	case MilestoneAmount:
+		return "AMOUNT"
+	case MilestonePercentage:
+		return "PERCENTAGE"
+	default:
+		return ""
+	}
+}
+
+func MilestoneKindFromString(s string) MilestoneKind {
+	switch s {
+	case "AMOUNT":
+		return MilestoneAmount
+	case "PERCENTAGE":
+		return MilestonePercentage
+	default:
+		return 0
+	}
+}
+
+type GoalEventType int
+
+const (
+	GoalEventMilestoneReached GoalEventType = iota + 1
+	GoalEventMilestoneLost
+)
+
+func (t GoalEventType) String() string {
+	switch t {
+	case GoalEventMilestoneReached:
+		return "MILESTONE_REACHED"
+	case GoalEventMilestoneLost:
+		return "MILESTONE_LOST"
+	default:
+		return ""
+	}
+}
+
+type GoalMilestone struct {
+	ID         int64
+	Goal       *Goal
+	Name       string
+	Kind       MilestoneKind
+	Amount     money.Money
+	Percentage float64
+	TargetDate *time.Time
+	ReachedAt  *time.Time
+	CreatedAt  time.Time
+	Version    int
+}
+
+type GoalMilestoneDTO struct {
+	ID           *int64       `json:"milestone_id"`
+	GoalID       *int64       `json:"goal_id"`
+	Name         *string      `json:"name"`
+	Kind         *string      `json:"kind"`
+	Amount       *money.Money `json:"amount,omitempty"`
+	Percentage   *float64     `json:"percentage,omitempty"`
+	TargetAmount *money.Money `json:"target_amount,omitempty"`
+	TargetDate   *string      `json:"target_date,omitempty"`
+	Reached      *bool        `json:"reached"`
+	ReachedAt    *time.Time   `json:"reached_at,omitempty"`
+	OnTime       *bool        `json:"on_time,omitempty"`
+	CreatedAt    *time.Time   `json:"created_at"`
+	Version      *int         `json:"version"`
+}
+
+type GoalEvent struct {
+	ID          int64
+	GoalID      int64
+	Type        GoalEventType
+	MilestoneID *int64
+	Amount      money.Money
+	Description string
+	CreatedAt   time.Time
+}
+
+type GoalEventDTO struct {
+	ID          *int64       `json:"event_id"`
+	Type        *string      `json:"type"`
+	MilestoneID *int64       `json:"milestone_id,omitempty"`
+	Amount      *money.Money `json:"amount"`
+	Description *string      `json:"description"`
+	CreatedAt   *time.Time   `json:"created_at"`
+}
+
+func (m *GoalMilestone) Target() money.Money {
+	if m.Kind == MilestonePercentage && m.Goal != nil {
+		return m.Goal.Amount.MulRate(m.Percentage / 100)
+	}
+	return m.Amount
+}
+
+func (m *GoalMilestone) ToDTO() *GoalMilestoneDTO {
+	kind := m.Kind.String()
+	reached := m.ReachedAt != nil
+
+	dto := &GoalMilestoneDTO{
+		ID:        &m.ID,
+		Name:      &m.Name,
+		Kind:      &kind,
+		Reached:   &reached,
+		ReachedAt: m.ReachedAt,
+		CreatedAt: &m.CreatedAt,
+		Version:   &m.Version,
+	}
+
+	if m.Goal != nil {
+		target := m.Target()
+		dto.GoalID = &m.Goal.ID
+		dto.TargetAmount = &target
+	}
+
+	switch m.Kind {
+	case MilestoneAmount:
+		dto.Amount = &m.Amount
+	case MilestonePercentage:
+		dto.Percentage = &m.Percentage
+	}
+
+	if m.TargetDate != nil {
+		date := m.TargetDate.Format(DateLayout)
+		dto.TargetDate = &date
+
+		if m.ReachedAt != nil {
+			onTime := !m.ReachedAt.After(m.TargetDate.AddDate(0, 0, 1))
+			dto.OnTime = &onTime
+		}
+	}
+
+	return dto
+}
+
+func (m *GoalMilestoneDTO) ToModel() *GoalMilestone {
+	milestone := &GoalMilestone{}
+
+	if m.ID != nil {
+		milestone.ID = *m.ID
+	}
+	if m.GoalID != nil {
+		milestone.Goal = &Goal{ID: *m.GoalID}
+	}
+	if m.Name != nil {
+		milestone.Name = *m.Name
+	}
+	if m.Kind != nil {
+		milestone.Kind = MilestoneKindFromString(*m.Kind)
+	}
+	if m.Amount != nil {
+		milestone.Amount = *m.Amount
+	}
+	if m.Percentage != nil {
+		milestone.Percentage = *m.Percentage
+	}
+	if m.TargetDate != nil {
+		parsedTime, _ := time.Parse(DateLayout, *m.TargetDate)
+		milestone.TargetDate = &parsedTime
+	}
+	if m.Version != nil {
+		milestone.Version = *m.Version
+	}
+	return milestone
+}
+
+func (m *GoalMilestone) ValidateGoalMilestone(v *validator.Validator) {
+	v.Check(m.Goal != nil && m.Goal.ID > 0, "goal_id", "must be provided")
+	v.Check(m.Name != "", "name", "must be provided")
+	v.Check(len(m.Name) <= 255, "name", "must not be more than 255 bytes long")
+	v.Check(m.Kind != 0, "kind", "must be AMOUNT or PERCENTAGE")
+
+	switch m.Kind {
+	case MilestoneAmount:
+		v.Check(m.Amount.IsPositive(), "amount", "must be positive")
+	case MilestonePercentage:
+		v.Check(m.Percentage > 0 && m.Percentage <= 100, "percentage", "must be greater than 0 and at most 100")
+	}
+
+	if m.TargetDate != nil {
+		v.Check(!m.TargetDate.IsZero(), "target_date", "must be a valid date in the format YYYY-MM-DD")
+	}
+}
+
+func (e *GoalEvent) ToDTO() *GoalEventDTO {
+	eventType := e.Type.String()
+
+	return &GoalEventDTO{
+		ID:          &e.ID,
+		Type:        &eventType,
+		MilestoneID: e.MilestoneID,
+		Amount:      &e.Amount,
+		Description: &e.Description,
+		CreatedAt:   &e.CreatedAt,
+	}
+}
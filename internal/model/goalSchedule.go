@@ -0,0 +1,199 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"time"
+)
+
+type ContributionMode int
+
+const (
+	ContributionFixed ContributionMode = iota + 1
+	ContributionInstallment
+)
+
+func (m ContributionMode) String() string {
+	switch m {
+	case ContributionFixed:
+		return "FIXED"
+	case ContributionInstallment:
+		return "INSTALLMENT"
+	default:
+		return ""
+	}
+}
+
+func ContributionModeFromString(s string) ContributionMode {
+	switch s {
+	case "FIXED":
+		return ContributionFixed
+	case "INSTALLMENT":
+		return ContributionInstallment
+	default:
+		return 0
+	}
+}
+
+type ScheduleRunStatus int
+
+const (
+	ScheduleRunExecuted ScheduleRunStatus = iota + 1
+	ScheduleRunSkipped
+)
+
+func (s ScheduleRunStatus) String() string {
+	switch s {
+	case ScheduleRunExecuted:
+		return "EXECUTED"
+	case ScheduleRunSkipped:
+		return "SKIPPED"
+	default:
+		return ""
+	}
+}
+
+type GoalSchedule struct {
+	ID         int64
+	CreatedAt  time.Time
+	User       *User
+	Goal       *Goal
+	Mode       ContributionMode
+	Amount     money.Money
+	DayOfMonth int
+	NextRun    time.Time
+	Paused     bool
+	Deleted    bool
+	Version    int
+	Runs       []*GoalScheduleRun
+}
+
+type GoalScheduleDTO struct {
+	ID         *int64                `json:"schedule_id"`
+	GoalID     *int64                `json:"goal_id"`
+	GoalName   *string               `json:"goal_name,omitempty"`
+	Mode       *string               `json:"mode"`
+	Amount     *money.Money          `json:"amount,omitempty"`
+	DayOfMonth *int                  `json:"day_of_month"`
+	NextRun    *string               `json:"next_run"`
+	Paused     *bool                 `json:"paused"`
+	Runs       []*GoalScheduleRunDTO `json:"runs,omitempty"`
+	CreatedAt  *time.Time            `json:"created_at"`
+	Version    *int                  `json:"version"`
+}
+
+type GoalScheduleRun struct {
+	ID             int64
+	ScheduleID     int64
+	DueDate        time.Time
+	Status         ScheduleRunStatus
+	Amount         money.Money
+	GoalProgressID *int64
+	Reason         string
+	CreatedAt      time.Time
+}
+
+type GoalScheduleRunDTO struct {
+	ID             *int64       `json:"run_id"`
+	DueDate        *string      `json:"due_date"`
+	Status         *string      `json:"status"`
+	Amount         *money.Money `json:"amount"`
+	GoalProgressID *int64       `json:"goal_progress_id,omitempty"`
+	Reason         *string      `json:"reason,omitempty"`
+	CreatedAt      *time.Time   `json:"created_at"`
+}
+
+func NextScheduleRun(dayOfMonth int, from time.Time) time.Time {
+	next := time.Date(from.Year(), from.Month(), dayOfMonth, 0, 0, 0, 0, time.UTC)
+	if next.Before(time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)) {
+		next = next.AddDate(0, 1, 0)
+	}
+	return next
+}
+
+func (s *GoalSchedule) ToDTO() *GoalScheduleDTO {
+	mode := s.Mode.String()
+	nextRun := s.NextRun.Format(DateLayout)
+
+	dto := &GoalScheduleDTO{
+		ID:         &s.ID,
+		Mode:       &mode,
+		DayOfMonth: &s.DayOfMonth,
+		NextRun:    &nextRun,
+		Paused:     &s.Paused,
+		CreatedAt:  &s.CreatedAt,
+		Version:    &s.Version,
+	}
+
+	if s.Goal != nil {
+		dto.GoalID = &s.Goal.ID
+		if s.Goal.Name != "" {
+			dto.GoalName = &s.Goal.Name
+		}
+	}
+	if s.Mode == ContributionFixed {
+		dto.Amount = &s.Amount
+	}
+	if s.Runs != nil {
+		dto.Runs = make([]*GoalScheduleRunDTO, len(s.Runs))
+		for i, run := range s.Runs {
+			dto.Runs[i] = run.ToDTO()
+		}
+	}
+
+	return dto
+}
+
+func (m *GoalScheduleDTO) ToModel() *GoalSchedule {
+	schedule := &GoalSchedule{}
+
+	if m.ID != nil {
+		schedule.ID = *m.ID
+	}
+	if m.GoalID != nil {
+		schedule.Goal = &Goal{ID: *m.GoalID}
+	}
+	if m.Mode != nil {
+		schedule.Mode = ContributionModeFromString(*m.Mode)
+	}
+	if m.Amount != nil {
+		schedule.Amount = *m.Amount
+	}
+	if m.DayOfMonth != nil {
+		schedule.DayOfMonth = *m.DayOfMonth
+	}
+	if m.Version != nil {
+		schedule.Version = *m.Version
+	}
+	return schedule
+}
+
+func (s *GoalSchedule) ValidateGoalSchedule(v *validator.Validator) {
+	v.Check(s.Goal != nil && s.Goal.ID > 0, "goal_id", "must be provided")
+	v.Check(s.Mode != 0, "mode", "must be FIXED or INSTALLMENT")
+	v.Check(s.DayOfMonth >= 1 && s.DayOfMonth <= 28, "day_of_month", "must be between 1 and 28")
+
+	if s.Mode == ContributionFixed {
+		v.Check(s.Amount.IsPositive(), "amount", "must be positive for a fixed contribution")
+	}
+}
+
+func (r *GoalScheduleRun) ToDTO() *GoalScheduleRunDTO {
+	dueDate := r.DueDate.Format(DateLayout)
+	status := r.Status.String()
+
+	dto := &GoalScheduleRunDTO{
+		ID:             &r.ID,
+		DueDate:        &dueDate,
+		Status:         &status,
+		Amount:         &r.Amount,
+		GoalProgressID: r.GoalProgressID,
+		CreatedAt:      &r.CreatedAt,
+	}
+
+	if r.Reason != "" {
+		dto.Reason = &r.Reason
+	}
+
+	return dto
+}
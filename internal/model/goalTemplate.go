@@ -0,0 +1,92 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"time"
+)
+
+type GoalTemplateKind int
+
+const (
+	GoalTemplateEmergencyFund GoalTemplateKind = iota + 1
+	GoalTemplateVacation
+	GoalTemplateThirteenthSalary
+)
+
+var GoalTemplateKinds = []GoalTemplateKind{
+	GoalTemplateEmergencyFund,
+	GoalTemplateVacation,
+	GoalTemplateThirteenthSalary,
+}
+
+func (k GoalTemplateKind) String() string {
+	switch k {
+	case GoalTemplateEmergencyFund:
+		return "EMERGENCY_FUND"
+	case GoalTemplateVacation:
+		return "VACATION"
+	case GoalTemplateThirteenthSalary:
+		return "THIRTEENTH_SALARY"
+	default:
+		return ""
+	}
+}
+
+func GoalTemplateKindFromString(s string) GoalTemplateKind {
+	switch s {
+	case "EMERGENCY_FUND":
+		return GoalTemplateEmergencyFund
+	case "VACATION":
+		return GoalTemplateVacation
+	case "THIRTEENTH_SALARY":
+		return GoalTemplateThirteenthSalary
+	default:
+		return 0
+	}
+}
+
+type GoalTemplate struct {
+	Kind        string               `json:"kind"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Preview     *GoalTemplatePreview `json:"preview,omitempty"`
+}
+
+type GoalTemplateInput struct {
+	Name        *string      `json:"name"`
+	Description *string      `json:"description"`
+	Color       *string      `json:"color"`
+	Amount      *money.Money `json:"amount"`
+	Months      *int         `json:"months"`
+	Deadline    *string      `json:"deadline"`
+}
+
+type GoalTemplateHistory struct {
+	Months          int         `json:"months"`
+	AverageExpenses money.Money `json:"average_monthly_expenses"`
+	AverageIncome   money.Money `json:"average_monthly_income"`
+}
+
+type GoalTemplatePreview struct {
+	Kind          string              `json:"kind"`
+	Goal          *GoalDTO            `json:"goal"`
+	MonthsCovered int                 `json:"months_covered,omitempty"`
+	History       GoalTemplateHistory `json:"history"`
+}
+
+func (i *GoalTemplateInput) Validate(v *validator.Validator) {
+	if i.Name != nil {
+		v.Check(*i.Name != "", "name", "must not be empty")
+	}
+	if i.Amount != nil {
+		v.Check(i.Amount.IsPositive(), "amount", "must be greater than zero")
+	}
+	if i.Months != nil {
+		v.Check(*i.Months >= 1 && *i.Months <= 24, "months", "must be between 1 and 24")
+	}
+	if i.Deadline != nil {
+		_, err := time.Parse(GoalDeadlineLayout, *i.Deadline)
+		v.Check(err == nil, "deadline", "must be a valid date in the format DD/MM/YYYY")
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"financas/utils/validator"
+	"time"
+)
+
+type InterestRate struct {
+	ID         int64
+	User       *User
+	Month      time.Time
+	AnnualRate float64
+	CreatedAt  time.Time
+	Version    int
+}
+
+type InterestRateDTO struct {
+	ID         *int64     `json:"rate_id"`
+	Month      *string    `json:"month"`
+	AnnualRate *float64   `json:"annual_rate"`
+	CreatedAt  *time.Time `json:"created_at"`
+	Version    *int       `json:"version"`
+}
+
+func (r *InterestRate) ToDTO() *InterestRateDTO {
+	month := r.Month.Format(StatementMonthLayout)
+
+	return &InterestRateDTO{
+		ID:         &r.ID,
+		Month:      &month,
+		AnnualRate: &r.AnnualRate,
+		CreatedAt:  &r.CreatedAt,
+		Version:    &r.Version,
+	}
+}
+
+func (m *InterestRateDTO) ToModel() *InterestRate {
+	rate := &InterestRate{}
+
+	if m.ID != nil {
+		rate.ID = *m.ID
+	}
+	if m.Month != nil {
+		parsedTime, err := time.Parse(StatementMonthLayout, *m.Month)
+		if err == nil {
+			rate.Month = parsedTime
+		}
+	}
+	if m.AnnualRate != nil {
+		rate.AnnualRate = *m.AnnualRate
+	}
+	if m.Version != nil {
+		rate.Version = *m.Version
+	}
+	return rate
+}
+
+func (r *InterestRate) ValidateInterestRate(v *validator.Validator) {
+	v.Check(!r.Month.IsZero(), "month", "must be a valid month in the format YYYY-MM")
+	v.Check(!r.Month.After(time.Now().UTC()), "month", "must not be in the future")
+	v.Check(r.AnnualRate >= 0 && r.AnnualRate <= 100, "annual_rate", "must be between 0 and 100")
+}
@@ -0,0 +1,343 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"strings"
+	"time"
+)
+
+type InvestmentKind int
+
+const (
+	InvestmentStock InvestmentKind = iota + 1
+	InvestmentETF
+	InvestmentREIT
+	InvestmentFund
+	InvestmentFixedIncome
+	InvestmentCrypto
+)
+
+func (k InvestmentKind) String() string {
+	switch k {
+	case InvestmentStock:
+		return "STOCK"
+	case InvestmentETF:
+		return "ETF"
+	case InvestmentREIT:
+		return "REIT"
+	case InvestmentFund:
+		return "FUND"
+	case InvestmentFixedIncome:
+		return "FIXED_INCOME"
+	case InvestmentCrypto:
+		return "CRYPTO"
+	default:
+		return ""
+	}
+}
+
+func InvestmentKindFromString(s string) InvestmentKind {
+	for k := InvestmentStock; k <= InvestmentCrypto; k++ {
+		if k.String() == s {
+			return k
+		}
+	}
+	return 0
+}
+
+type OperationType int
+
+const (
+	OperationBuy OperationType = iota + 1
+	OperationSell
+	OperationDividend
+)
+
+func (t OperationType) String() string {
+	switch t {
+	case OperationBuy:
+		return "BUY"
+	case OperationSell:
+		return "SELL"
+	case OperationDividend:
+		return "DIVIDEND"
+	default:
+		return ""
+	}
+}
+
+func OperationTypeFromString(s string) OperationType {
+	switch s {
+	case "BUY":
+		return OperationBuy
+	case "SELL":
+		return OperationSell
+	case "DIVIDEND":
+		return OperationDividend
+	default:
+		return 0
+	}
+}
+
+type Investment struct {
+	ID         int64
+	CreatedAt  time.Time
+	User       *User
+	Ticker     string
+	Name       string
+	Kind       InvestmentKind
+	Currency   string
+	Deleted    bool
+	Version    int
+	Operations []*InvestmentOperation
+	Prices     []*InvestmentPrice
+}
+
+type InvestmentDTO struct {
+	ID        *int64     `json:"investment_id"`
+	Ticker    *string    `json:"ticker"`
+	Name      *string    `json:"name"`
+	Kind      *string    `json:"kind"`
+	Currency  *string    `json:"currency"`
+	Position  *Position  `json:"position,omitempty"`
+	CreatedAt *time.Time `json:"created_at"`
+	Version   *int       `json:"version"`
+}
+
+type InvestmentOperation struct {
+	ID           int64
+	InvestmentID int64
+	Type         OperationType
+	Date         time.Time
+	Quantity     float64
+	Price        money.Money
+	Fees         money.Money
+	Amount       money.Money
+	CreatedAt    time.Time
+}
+
+type InvestmentOperationDTO struct {
+	ID        *int64       `json:"operation_id"`
+	Type      *string      `json:"type"`
+	Date      *string      `json:"date"`
+	Quantity  *float64     `json:"quantity"`
+	Price     *money.Money `json:"price"`
+	Fees      *money.Money `json:"fees"`
+	Amount    *money.Money `json:"amount"`
+	CreatedAt *time.Time   `json:"created_at"`
+}
+
+type InvestmentPrice struct {
+	ID           int64
+	InvestmentID int64
+	Date         time.Time
+	Price        money.Money
+	CreatedAt    time.Time
+}
+
+type InvestmentPriceDTO struct {
+	ID        *int64       `json:"price_id"`
+	Date      *string      `json:"date"`
+	Price     *money.Money `json:"price"`
+	CreatedAt *time.Time   `json:"created_at"`
+}
+
+type Position struct {
+	InvestmentID   int64       `json:"investment_id"`
+	Ticker         string      `json:"ticker,omitempty"`
+	Name           string      `json:"name"`
+	Currency       string      `json:"currency"`
+	Quantity       float64     `json:"quantity"`
+	AverageCost    money.Money `json:"average_cost"`
+	CostBasis      money.Money `json:"cost_basis"`
+	MarketPrice    money.Money `json:"market_price"`
+	PriceDate      string      `json:"price_date,omitempty"`
+	MarketValue    money.Money `json:"market_value"`
+	UnrealizedGain money.Money `json:"unrealized_gain"`
+	RealizedGain   money.Money `json:"realized_gain"`
+	Dividends      money.Money `json:"dividends"`
+}
+
+type InvestmentReturn struct {
+	InvestmentID        int64       `json:"investment_id,omitempty"`
+	Ticker              string      `json:"ticker,omitempty"`
+	Name                string      `json:"name"`
+	Currency            string      `json:"currency"`
+	StartValue          money.Money `json:"start_value"`
+	EndValue            money.Money `json:"end_value"`
+	NetContributions    money.Money `json:"net_contributions"`
+	Dividends           money.Money `json:"dividends"`
+	Gain                money.Money `json:"gain"`
+	TimeWeightedReturn  *float64    `json:"time_weighted_return"`
+	MoneyWeightedReturn *float64    `json:"money_weighted_return"`
+	AnnualizedMWR       *float64    `json:"annualized_money_weighted_return"`
+}
+
+type PortfolioReport struct {
+	Currency  string              `json:"currency"`
+	StartDate string              `json:"start_date"`
+	EndDate   string              `json:"end_date"`
+	Total     *InvestmentReturn   `json:"total"`
+	Assets    []*InvestmentReturn `json:"assets"`
+	Positions []*Position         `json:"positions"`
+}
+
+func NormalizeTicker(ticker string) string {
+	return strings.ToUpper(strings.TrimSpace(ticker))
+}
+
+func (i *Investment) ToDTO() *InvestmentDTO {
+	kind := i.Kind.String()
+
+	dto := &InvestmentDTO{
+		ID:        &i.ID,
+		Name:      &i.Name,
+		Kind:      &kind,
+		Currency:  &i.Currency,
+		CreatedAt: &i.CreatedAt,
+		Version:   &i.Version,
+	}
+
+	if i.Ticker != "" {
+		dto.Ticker = &i.Ticker
+	}
+
+	return dto
+}
+
+func (m *InvestmentDTO) ToModel() *Investment {
+	investment := &Investment{}
+
+	if m.ID != nil {
+		investment.ID = *m.ID
+	}
+	if m.Ticker != nil {
+		investment.Ticker = NormalizeTicker(*m.Ticker)
+	}
+	if m.Name != nil {
+		investment.Name = *m.Name
+	}
+	if m.Kind != nil {
+		investment.Kind = InvestmentKindFromString(*m.Kind)
+	}
+	if m.Currency != nil {
+		investment.Currency = NormalizeCurrency(*m.Currency)
+	}
+	if m.Version != nil {
+		investment.Version = *m.Version
+	}
+	return investment
+}
+
+func (i *Investment) ValidateInvestment(v *validator.Validator) {
+	v.Check(i.Name != "", "name", "must be provided")
+	v.Check(len(i.Name) <= 255, "name", "must not be more than 255 bytes long")
+	v.Check(len(i.Ticker) <= 32, "ticker", "must not be more than 32 bytes long")
+	v.Check(i.Kind != 0, "kind", "invalid investment kind")
+	v.Check(i.Ticker != "" || i.Kind == InvestmentFixedIncome, "ticker", "must be provided for market-traded investments")
+	ValidateCurrency(v, "currency", i.Currency)
+}
+
+func (o *InvestmentOperation) ToDTO() *InvestmentOperationDTO {
+	operationType := o.Type.String()
+	date := o.Date.Format(DateLayout)
+
+	return &InvestmentOperationDTO{
+		ID:        &o.ID,
+		Type:      &operationType,
+		Date:      &date,
+		Quantity:  &o.Quantity,
+		Price:     &o.Price,
+		Fees:      &o.Fees,
+		Amount:    &o.Amount,
+		CreatedAt: &o.CreatedAt,
+	}
+}
+
+func (m *InvestmentOperationDTO) ToModel() *InvestmentOperation {
+	operation := &InvestmentOperation{}
+
+	if m.Type != nil {
+		operation.Type = OperationTypeFromString(*m.Type)
+	}
+	if m.Date != nil {
+		parsedTime, err := time.Parse(DateLayout, *m.Date)
+		if err == nil {
+			operation.Date = parsedTime
+		}
+	}
+	if m.Quantity != nil {
+		operation.Quantity = *m.Quantity
+	}
+	if m.Price != nil {
+		operation.Price = *m.Price
+	}
+	if m.Fees != nil {
+		operation.Fees = *m.Fees
+	}
+	if m.Amount != nil {
+		operation.Amount = *m.Amount
+	}
+	return operation
+}
+
+func (o *InvestmentOperation) ValidateInvestmentOperation(v *validator.Validator) {
+	v.Check(o.Type != 0, "type", "must be BUY, SELL or DIVIDEND")
+	v.Check(!o.Date.IsZero(), "date", "must be a valid date in the format YYYY-MM-DD")
+	v.Check(!o.Date.After(time.Now().UTC()), "date", "must not be in the future")
+	v.Check(!o.Fees.IsNegative(), "fees", "must not be negative")
+
+	switch o.Type {
+	case OperationBuy, OperationSell:
+		v.Check(o.Quantity > 0, "quantity", "must be greater than zero")
+		v.Check(o.Price.IsPositive(), "price", "must be greater than zero")
+	case OperationDividend:
+		v.Check(o.Amount.IsPositive(), "amount", "must be greater than zero")
+	}
+}
+
+func (o *InvestmentOperation) CalculateAmount() {
+	switch o.Type {
+	case OperationBuy:
+		o.Amount = o.Price.MulRate(o.Quantity).Add(o.Fees)
+	case OperationSell:
+		o.Amount = o.Price.MulRate(o.Quantity).Sub(o.Fees)
+	case OperationDividend:
+		o.Quantity = 0
+		o.Price = money.Zero
+		o.Amount = o.Amount.Sub(o.Fees)
+	}
+}
+
+func (p *InvestmentPrice) ToDTO() *InvestmentPriceDTO {
+	date := p.Date.Format(DateLayout)
+
+	return &InvestmentPriceDTO{
+		ID:        &p.ID,
+		Date:      &date,
+		Price:     &p.Price,
+		CreatedAt: &p.CreatedAt,
+	}
+}
+
+func (m *InvestmentPriceDTO) ToModel() *InvestmentPrice {
+	price := &InvestmentPrice{}
+
+	if m.Date != nil {
+		parsedTime, err := time.Parse(DateLayout, *m.Date)
+		if err == nil {
+			price.Date = parsedTime
+		}
+	}
+	if m.Price != nil {
+		price.Price = *m.Price
+	}
+	return price
+}
+
+func (p *InvestmentPrice) ValidateInvestmentPrice(v *validator.Validator) {
+	v.Check(!p.Date.IsZero(), "date", "must be a valid date in the format YYYY-MM-DD")
+	v.Check(!p.Date.After(time.Now().UTC()), "date", "must not be in the future")
+	v.Check(!p.Price.IsNegative(), "price", "must not be negative")
+}
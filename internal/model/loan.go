@@ -0,0 +1,263 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"time"
+)
+
+const MaxLoanTerm = 600
+
+type AmortizationSystem int
+
+const (
+	AmortizationSAC AmortizationSystem = iota + 1
+	AmortizationPrice
+)
+
+func (s AmortizationSystem) String() string {
+	switch s {
+	case AmortizationSAC:
+		return "SAC"
+	case AmortizationPrice:
+		return "PRICE"
+	default:
+		return ""
+	}
+}
+
+func AmortizationSystemFromString(s string) AmortizationSystem {
+	switch s {
+	case "SAC":
+		return AmortizationSAC
+	case "PRICE":
+		return AmortizationPrice
+	default:
+		return 0
+	}
+}
+
+type PrepaymentMode int
+
+const (
+	PrepaymentReduceTerm PrepaymentMode = iota + 1
+	PrepaymentReduceInstallment
+)
+
+func (m PrepaymentMode) String() string {
+	switch m {
+	case PrepaymentReduceTerm:
+		return "REDUCE_TERM"
+	case PrepaymentReduceInstallment:
+		return "REDUCE_INSTALLMENT"
+	default:
+		return ""
+	}
+}
+
+func PrepaymentModeFromString(s string) PrepaymentMode {
+	switch s {
+	case "REDUCE_TERM":
+		return PrepaymentReduceTerm
+	case "REDUCE_INSTALLMENT":
+		return PrepaymentReduceInstallment
+	default:
+		return 0
+	}
+}
+
+type Loan struct {
+	ID          int64
+	CreatedAt   time.Time
+	User        *User
+	Name        string
+	System      AmortizationSystem
+	Principal   money.Money
+	AnnualRate  float64
+	Term        int
+	StartDate   time.Time
+	Currency    string
+	Deleted     bool
+	Version     int
+	Prepayments []*LoanPrepayment
+	Payments    []*LoanPayment
+}
+
+type LoanDTO struct {
+	ID         *int64       `json:"loan_id"`
+	Name       *string      `json:"name"`
+	System     *string      `json:"system"`
+	Principal  *money.Money `json:"principal"`
+	AnnualRate *float64     `json:"annual_rate"`
+	Term       *int         `json:"term"`
+	StartDate  *string      `json:"start_date"`
+	Currency   *string      `json:"currency"`
+	CreatedAt  *time.Time   `json:"created_at,omitempty"`
+	Version    *int         `json:"version,omitempty"`
+}
+
+type LoanPrepayment struct {
+	ID        int64
+	LoanID    int64
+	Amount    money.Money
+	Date      time.Time
+	Mode      PrepaymentMode
+	CreatedAt time.Time
+}
+
+type LoanPrepaymentDTO struct {
+	ID        *int64       `json:"prepayment_id"`
+	Amount    *money.Money `json:"amount"`
+	Date      *string      `json:"date"`
+	Mode      *string      `json:"mode"`
+	CreatedAt *time.Time   `json:"created_at,omitempty"`
+}
+
+type LoanPayment struct {
+	ID            int64
+	LoanID        int64
+	Installment   int
+	TransactionID int64
+	CreatedAt     time.Time
+}
+
+type LoanInstallment struct {
+	Number        int         `json:"number"`
+	DueDate       string      `json:"due_date"`
+	Amortization  money.Money `json:"amortization"`
+	Interest      money.Money `json:"interest"`
+	Payment       money.Money `json:"payment"`
+	Prepayment    money.Money `json:"prepayment"`
+	Balance       money.Money `json:"balance"`
+	Paid          bool        `json:"paid"`
+	TransactionID *int64      `json:"transaction_id,omitempty"`
+}
+
+type LoanSchedule struct {
+	Loan             *LoanDTO             `json:"loan"`
+	MonthlyRate      float64              `json:"monthly_rate"`
+	Term             int                  `json:"term"`
+	TotalPaid        money.Money          `json:"total_paid"`
+	TotalInterest    money.Money          `json:"total_interest"`
+	TotalPrepaid     money.Money          `json:"total_prepaid"`
+	PaidInstallments int                  `json:"paid_installments"`
+	OutstandingDebt  money.Money          `json:"outstanding_debt"`
+	NextInstallment  *LoanInstallment     `json:"next_installment"`
+	Prepayments      []*LoanPrepaymentDTO `json:"prepayments"`
+	Installments     []*LoanInstallment   `json:"installments"`
+}
+
+func (l *Loan) DueDate(number int) time.Time {
+	first := time.Date(l.StartDate.Year(), l.StartDate.Month()+time.Month(number-1), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(l.StartDate.Day(), lastDay)-1)
+}
+
+func (l *Loan) ToDTO() *LoanDTO {
+	system := l.System.String()
+	startDate := l.StartDate.Format(DateLayout)
+
+	dto := &LoanDTO{
+		ID:         &l.ID,
+		Name:       &l.Name,
+		System:     &system,
+		Principal:  &l.Principal,
+		AnnualRate: &l.AnnualRate,
+		Term:       &l.Term,
+		StartDate:  &startDate,
+		Currency:   &l.Currency,
+		Version:    &l.Version,
+	}
+
+	if !l.CreatedAt.IsZero() {
+		dto.CreatedAt = &l.CreatedAt
+	}
+
+	return dto
+}
+
+func (m *LoanDTO) ToModel() *Loan {
+	loan := &Loan{}
+
+	if m.ID != nil {
+		loan.ID = *m.ID
+	}
+	if m.Name != nil {
+		loan.Name = *m.Name
+	}
+	if m.System != nil {
+		loan.System = AmortizationSystemFromString(*m.System)
+	}
+	if m.Principal != nil {
+		loan.Principal = *m.Principal
+	}
+	if m.AnnualRate != nil {
+		loan.AnnualRate = *m.AnnualRate
+	}
+	if m.Term != nil {
+		loan.Term = *m.Term
+	}
+	if m.StartDate != nil {
+		parsedTime, err := time.Parse(DateLayout, *m.StartDate)
+		if err == nil {
+			loan.StartDate = parsedTime
+		}
+	}
+	if m.Currency != nil {
+		loan.Currency = NormalizeCurrency(*m.Currency)
+	}
+	if m.Version != nil {
+		loan.Version = *m.Version
+	}
+	return loan
+}
+
+func (l *Loan) ValidateLoan(v *validator.Validator) {
+	v.Check(l.Name != "", "name", "must be provided")
+	v.Check(len(l.Name) <= 255, "name", "must not be more than 255 bytes long")
+	v.Check(l.System != 0, "system", "must be SAC or PRICE")
+	v.Check(l.Principal.IsPositive(), "principal", "must be greater than zero")
+	v.Check(l.AnnualRate >= 0, "annual_rate", "must not be negative")
+	v.Check(l.AnnualRate <= 1000, "annual_rate", "must not be greater than 1000")
+	v.Check(l.Term > 0, "term", "must be greater than zero")
+	v.Check(l.Term <= MaxLoanTerm, "term", "must not be greater than 600 months")
+	v.Check(!l.StartDate.IsZero(), "start_date", "must be a valid date in the format YYYY-MM-DD")
+	ValidateCurrency(v, "currency", l.Currency)
+}
+
+func (p *LoanPrepayment) ToDTO() *LoanPrepaymentDTO {
+	date := p.Date.Format(DateLayout)
+	mode := p.Mode.String()
+
+	return &LoanPrepaymentDTO{
+		ID:        &p.ID,
+		Amount:    &p.Amount,
+		Date:      &date,
+		Mode:      &mode,
+		CreatedAt: &p.CreatedAt,
+	}
+}
+
+func (m *LoanPrepaymentDTO) ToModel() *LoanPrepayment {
+	prepayment := &LoanPrepayment{}
+
+	if m.Amount != nil {
+		prepayment.Amount = *m.Amount
+	}
+	if m.Date != nil {
+		parsedTime, err := time.Parse(DateLayout, *m.Date)
+		if err == nil {
+			prepayment.Date = parsedTime
+		}
+	}
+	if m.Mode != nil {
+		prepayment.Mode = PrepaymentModeFromString(*m.Mode)
+	}
+	return prepayment
+}
+
+func (p *LoanPrepayment) ValidateLoanPrepayment(v *validator.Validator) {
+	v.Check(p.Amount.IsPositive(), "amount", "must be greater than zero")
+	v.Check(!p.Date.IsZero(), "date", "must be a valid date in the format YYYY-MM-DD")
+	v.Check(p.Mode != 0, "mode", "must be REDUCE_TERM or REDUCE_INSTALLMENT")
+}
@@ -0,0 +1,290 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact monetary amount stored as an integer number of cents.
+// Every operation that can produce fractions of a cent rounds half away
+// from zero, so 0.005 becomes 0.01 and -0.005 becomes -0.01.
+type Money int64
+
+const Zero Money = 0
+
+const (
+	maxAmountLength  = 24
+	maxDecimalLength = 48
+)
+
+var ErrInvalidAmount = errors.New("invalid monetary amount")
+
+var (
+	amountRX  = regexp.MustCompile(`^-?\d+(\.\d{1,2})?$`)
+	decimalRX = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+)
+
+func FromCents(cents int64) Money {
+	return Money(cents)
+}
+
+func FromFloat(f float64) Money {
+	m, err := parseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Money(math.Round(f * 100))
+	}
+	return m
+}
+
+// Parse reads a user supplied amount such as "1234.5" or "-10.25". Only a
+// plain decimal with at most two fraction digits is accepted.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > maxAmountLength || !amountRX.MatchString(s) {
+		return 0, ErrInvalidAmount
+	}
+
+	return parseDecimal(s)
+}
+
+// parseDecimal reads a plain decimal of any precision, as returned by
+// PostgreSQL for NUMERIC expressions, rounding it to the nearest cent.
+func parseDecimal(s string) (Money, error) {
+	if len(s) > maxDecimalLength || !decimalRX.MatchString(s) {
+		return 0, ErrInvalidAmount
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidAmount
+	}
+
+	return fromRat(r.Mul(r, big.NewRat(100, 1)))
+}
+
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) Add(o Money) Money {
+	return m + o
+}
+
+func (m Money) Sub(o Money) Money {
+	return m - o
+}
+
+func (m Money) Neg() Money {
+	return -m
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+func (m Money) Mul(n int64) Money {
+	return m * Money(n)
+}
+
+func (m Money) Div(n int64) Money {
+	if n == 0 {
+		return 0
+	}
+	return roundDiv(int64(m), n)
+}
+
+func (m Money) MulRate(rate float64) Money {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		return Money(math.Round(float64(m) * rate))
+	}
+
+	result, err := fromRat(r.Mul(r, new(big.Rat).SetInt64(int64(m))))
+	if err != nil {
+		return Money(math.Round(float64(m) * rate))
+	}
+	return result
+}
+
+func (m Money) DivRate(rate float64) Money {
+	if rate == 0 {
+		return 0
+	}
+
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		return Money(math.Round(float64(m) / rate))
+	}
+
+	result, err := fromRat(new(big.Rat).Quo(new(big.Rat).SetInt64(int64(m)), r))
+	if err != nil {
+		return Money(math.Round(float64(m) / rate))
+	}
+	return result
+}
+
+// Percent returns part as a percentage of whole, rounded to two decimal places.
+func Percent(part, whole Money) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*10000) / 100
+}
+
+func Sum(values ...Money) Money {
+	var total Money
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func Min(a, b Money) Money {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func Max(a, b Money) Money {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		parsed, err := parseDecimal(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case string:
+		parsed, err := parseDecimal(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case int64:
+		*m = Money(v * 100)
+		return nil
+	case float64:
+		*m = FromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func fromRat(r *big.Rat) (Money, error) {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+
+	cents := q.Int64()
+	if negative {
+		cents = -cents
+	}
+	return Money(cents), nil
+}
+
+func roundDiv(a, b int64) Money {
+	if b < 0 {
+		a, b = -a, -b
+	}
+
+	q := a / b
+	r := a % b
+	if r < 0 {
+		r = -r
+	}
+
+	if 2*r >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Money(q)
+}
@@ -0,0 +1,33 @@
+package model
+
+import "slices"
+
+const (
+	PermissionUsersRead  = "users:read"
+	PermissionUsersWrite = "users:write"
+	PermissionStatsRead  = "stats:read"
+)
+
+type UserRole int
+
+const (
+	UserRoleUser UserRole = iota + 1
+	UserRoleAdmin
+)
+
+func (r UserRole) String() string {
+	switch r {
+	case UserRoleUser:
+		return "USER"
+	case UserRoleAdmin:
+		return "ADMIN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+type Permissions []string
+
+func (p Permissions) Include(code string) bool {
+	return slices.Contains(p, code)
+}
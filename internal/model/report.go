@@ -1,11 +1,17 @@
 package model
 
-import "time"
+import (
+	"financas/internal/model/money"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
 
 type FinancialSummary struct {
-	TotalIncome     float64           `json:"total_income"`
-	TotalExpenses   float64           `json:"total_expenses"`
-	Balance         float64           `json:"balance"`
+	TotalIncome     money.Money       `json:"total_income"`
+	TotalExpenses   money.Money       `json:"total_expenses"`
+	Balance         money.Money       `json:"balance"`
+	Currency        string            `json:"currency"`
 	CategorySummary []CategorySummary `json:"category_summary"`
 	MonthlyTrends   []MonthlyTrend    `json:"monthly_trends"`
 	Period          PeriodSummary     `json:"period"`
@@ -13,16 +19,16 @@ type FinancialSummary struct {
 
 type CategorySummary struct {
 	Category   *CategoryDTO `json:"category"`
-	Total      float64      `json:"total"`
+	Total      money.Money  `json:"total"`
 	Count      int          `json:"count"`
 	Percentage float64      `json:"percentage"`
 }
 
 type MonthlyTrend struct {
-	Month    string  `json:"month"`
-	Income   float64 `json:"income"`
-	Expenses float64 `json:"expenses"`
-	Balance  float64 `json:"balance"`
+	Month    string      `json:"month"`
+	Income   money.Money `json:"income"`
+	Expenses money.Money `json:"expenses"`
+	Balance  money.Money `json:"balance"`
 }
 
 type PeriodSummary struct {
@@ -30,3 +36,97 @@ type PeriodSummary struct {
 	EndDate   time.Time `json:"end_date"`
 	Days      int       `json:"days"`
 }
+
+type TrendRange struct {
+	Granularity string
+	Start       time.Time
+	End         time.Time
+	CategoryID  int64
+}
+
+type CategoryTotal struct {
+	Category *Category
+	Total    money.Money
+	Count    int
+}
+
+type PeriodTotal struct {
+	Start    time.Time
+	Income   money.Money
+	Expenses money.Money
+	Count    int
+}
+
+type ReportAggregates struct {
+	Currency   string
+	Categories []*CategoryTotal
+	Periods    []*PeriodTotal
+}
+
+type CategoryComparison struct {
+	Category        *CategoryDTO     `json:"category"`
+	Current         *CategorySummary `json:"current"`
+	Previous        *CategorySummary `json:"previous"`
+	Delta           money.Money      `json:"delta"`
+	DeltaPercentage *float64         `json:"delta_percentage"`
+	Status          string           `json:"status"`
+}
+
+type PeriodComparison struct {
+	Currency              string               `json:"currency"`
+	Current               PeriodSummary        `json:"current_period"`
+	Previous              PeriodSummary        `json:"previous_period"`
+	IncomeDelta           money.Money          `json:"income_delta"`
+	ExpensesDelta         money.Money          `json:"expenses_delta"`
+	BalanceDelta          money.Money          `json:"balance_delta"`
+	Categories            []CategoryComparison `json:"categories"`
+	NewCategories         []*CategoryDTO       `json:"new_categories"`
+	DisappearedCategories []*CategoryDTO       `json:"disappeared_categories"`
+	LargestMovers         []CategoryComparison `json:"largest_movers"`
+}
+
+const (
+	ComparisonPresetMonth  = "month"
+	ComparisonPresetYTD    = "ytd"
+	ComparisonPresetCustom = "custom"
+)
+
+const (
+	ComparisonStatusNew         = "NEW"
+	ComparisonStatusDisappeared = "DISAPPEARED"
+	ComparisonStatusChanged     = "CHANGED"
+	ComparisonStatusUnchanged   = "UNCHANGED"
+)
+
+const (
+	GranularityDay     = "day"
+	GranularityWeek    = "week"
+	GranularityMonth   = "month"
+	GranularityQuarter = "quarter"
+	GranularityYear    = "year"
+)
+
+var Granularities = []string{
+	GranularityDay,
+	GranularityWeek,
+	GranularityMonth,
+	GranularityQuarter,
+	GranularityYear,
+}
+
+type TimeSeriesPoint struct {
+	Label    string      `json:"label"`
+	Start    time.Time   `json:"start"`
+	Income   money.Money `json:"income"`
+	Expenses money.Money `json:"expenses"`
+	Balance  money.Money `json:"balance"`
+	Count    int         `json:"count"`
+}
+
+type TimeSeries struct {
+	Currency    string            `json:"currency"`
+	Granularity string            `json:"granularity"`
+	CategoryID  *int64            `json:"category_id,omitempty"`
+	Period      PeriodSummary     `json:"period"`
+	Points      []TimeSeriesPoint `json:"points"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"time"
+)
+
+const StatementMonthLayout = "2006-01"
+
+type MonthlyStatement struct {
+	Month          time.Time         `json:"month"`
+	Currency       string            `json:"currency"`
+	OpeningBalance money.Money       `json:"opening_balance"`
+	ClosingBalance money.Money       `json:"closing_balance"`
+	TotalIncome    money.Money       `json:"total_income"`
+	TotalExpenses  money.Money       `json:"total_expenses"`
+	Categories     []CategorySummary `json:"categories"`
+	Transactions   []*Transaction    `json:"-"`
+}
+
+type Statement struct {
+	ID        int64
+	CreatedAt time.Time
+	User      *User
+	Month     time.Time
+	Content   []byte
+	Size      int
+}
+
+type StatementDTO struct {
+	ID        *int64     `json:"statement_id"`
+	CreatedAt *time.Time `json:"created_at"`
+	Month     *string    `json:"month"`
+	Size      *int       `json:"size"`
+}
+
+func (s *Statement) ToDTO() *StatementDTO {
+	month := s.Month.Format(StatementMonthLayout)
+
+	return &StatementDTO{
+		ID:        &s.ID,
+		CreatedAt: &s.CreatedAt,
+		Month:     &month,
+		Size:      &s.Size,
+	}
+}
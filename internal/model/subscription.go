@@ -0,0 +1,151 @@
+package model
+
+import (
+	"financas/internal/model/money"
+	"financas/utils/validator"
+	"time"
+)
+
+type SubscriptionCadence int
+
+const (
+	SubscriptionWeekly SubscriptionCadence = iota + 1
+	SubscriptionMonthly
+	SubscriptionQuarterly
+	SubscriptionYearly
+)
+
+func (c SubscriptionCadence) String() string {
+	switch c {
+	case SubscriptionWeekly:
+		return "WEEKLY"
+	case SubscriptionMonthly:
+		return "MONTHLY"
+	case SubscriptionQuarterly:
+		return "QUARTERLY"
+	case SubscriptionYearly:
+		return "YEARLY"
+	default:
+		return ""
+	}
+}
+
+func (c SubscriptionCadence) Next(t time.Time) time.Time {
+	switch c {
+	case SubscriptionWeekly:
+		return t.AddDate(0, 0, 7)
+	case SubscriptionQuarterly:
+		return t.AddDate(0, 3, 0)
+	case SubscriptionYearly:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 1, 0)
+	}
+}
+
+type SubscriptionStatus int
+
+const (
+	SubscriptionConfirmed SubscriptionStatus = iota + 1
+	SubscriptionDismissed
+)
+
+func (s SubscriptionStatus) String() string {
+	switch s {
+	case SubscriptionConfirmed:
+		return "CONFIRMED"
+	case SubscriptionDismissed:
+		return "DISMISSED"
+	default:
+		return ""
+	}
+}
+
+func SubscriptionStatusFromString(s string) SubscriptionStatus {
+	switch s {
+	case "CONFIRMED":
+		return SubscriptionConfirmed
+	case "DISMISSED":
+		return SubscriptionDismissed
+	default:
+		return 0
+	}
+}
+
+const SubscriptionDetected = "DETECTED"
+
+type Subscription struct {
+	ID          int64
+	CreatedAt   time.Time
+	User        *User
+	Category    *Category
+	Merchant    string
+	Description string
+	Cadence     SubscriptionCadence
+	Amount      money.Money
+	Currency    string
+	Occurrences int
+	LastCharge  time.Time
+	NextCharge  time.Time
+	Status      SubscriptionStatus
+	Version     int
+}
+
+type SubscriptionDTO struct {
+	ID          *int64       `json:"subscription_id,omitempty"`
+	Merchant    *string      `json:"merchant"`
+	Description *string      `json:"description"`
+	Category    *CategoryDTO `json:"category"`
+	Cadence     *string      `json:"cadence"`
+	Amount      *money.Money `json:"average_amount"`
+	Currency    *string      `json:"currency"`
+	Occurrences *int         `json:"occurrences,omitempty"`
+	LastCharge  *string      `json:"last_charge"`
+	NextCharge  *string      `json:"next_charge"`
+	Status      *string      `json:"status"`
+	Version     *int         `json:"version,omitempty"`
+	CreatedAt   *time.Time   `json:"created_at,omitempty"`
+}
+
+func (s *Subscription) ToDTO() *SubscriptionDTO {
+	cadence := s.Cadence.String()
+	lastCharge := s.LastCharge.Format("2006-01-02")
+	nextCharge := s.NextCharge.Format("2006-01-02")
+
+	status := SubscriptionDetected
+	if s.Status != 0 {
+		status = s.Status.String()
+	}
+
+	dto := &SubscriptionDTO{
+		Merchant:    &s.Merchant,
+		Description: &s.Description,
+		Cadence:     &cadence,
+		Amount:      &s.Amount,
+		Currency:    &s.Currency,
+		LastCharge:  &lastCharge,
+		NextCharge:  &nextCharge,
+		Status:      &status,
+	}
+
+	if s.Category != nil {
+		dto.Category = s.Category.ToDTO()
+	}
+
+	if s.Occurrences > 0 {
+		dto.Occurrences = &s.Occurrences
+	}
+
+	if s.ID != 0 {
+		dto.ID = &s.ID
+		dto.Version = &s.Version
+		dto.CreatedAt = &s.CreatedAt
+	}
+
+	return dto
+}
+
+func ValidateSubscriptionMerchant(v *validator.Validator, merchant string) {
+	v.Check(merchant != "", "merchant", "must be provided")
+	v.Check(len(merchant) <= 500, "merchant", "must not be more than 500 bytes long")
+}
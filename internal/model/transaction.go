@@ -1,6 +1,7 @@
 package model
 
 import (
+	"financas/internal/model/money"
 	"financas/utils/validator"
 	"time"
 )
@@ -13,7 +14,8 @@ type Transaction struct {
 	User        *User
 	Category    *Category
 	Description string
-	Amount      float64
+	Amount      money.Money
+	Currency    string
 }
 
 type TransactionDTO struct {
@@ -22,7 +24,8 @@ type TransactionDTO struct {
 	User        *UserDTO     `json:"user"`
 	Category    *CategoryDTO `json:"category"`
 	Description *string      `json:"description"`
-	Amount      *float64     `json:"amount"`
+	Amount      *money.Money `json:"amount"`
+	Currency    *string      `json:"currency"`
 	CreatedAt   *time.Time   `json:"created_at"`
 }
 
@@ -34,6 +37,7 @@ func (t *Transaction) ToDTO() *TransactionDTO {
 	dto.Version = &t.Version
 	dto.Description = &t.Description
 	dto.Amount = &t.Amount
+	dto.Currency = &t.Currency
 
 	if t.User != nil {
 		dto.User = t.User.ToDTO()
@@ -67,6 +71,9 @@ func (t *TransactionDTO) ToModel() *Transaction {
 	if t.Amount != nil {
 		transaction.Amount = *t.Amount
 	}
+	if t.Currency != nil {
+		transaction.Currency = NormalizeCurrency(*t.Currency)
+	}
 
 	return transaction
 }
@@ -78,4 +85,5 @@ func (t *Transaction) ValidateTransaction(v *validator.Validator) {
 	v.Check(len(t.Description) <= 500, "description", "must not be more than 500 bytes long")
 	v.Check(t.Amount > 0, "amount", "must be positive")
 	v.Check(t.Amount != 0, "amount", "must be provided")
+	ValidateCurrency(v, "currency", t.Currency)
 }
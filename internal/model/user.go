@@ -18,16 +18,33 @@ type User struct {
 	Password  password
 	Phone     string
 	Activated bool
+	Disabled  bool
 	Cod       int
+	Role      UserRole
 	Version   int
 	Deleted   bool
+
+	BaseCurrency string
 }
 
 type UserDTO struct {
-	ID    int64  `json:"user_id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Phone string `json:"phone"`
+	ID           int64  `json:"user_id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	BaseCurrency string `json:"base_currency,omitempty"`
+}
+
+type UserAdminDTO struct {
+	ID        int64     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Activated bool      `json:"activated"`
+	Disabled  bool      `json:"disabled"`
+	Role      string    `json:"role"`
+	Version   int       `json:"version"`
 }
 
 type UserSaveDTO struct {
@@ -48,19 +65,35 @@ func (u *User) IsAnonymous() bool {
 
 func (u *User) ToDTO() *UserDTO {
 	return &UserDTO{
-		ID:    u.ID,
-		Name:  u.Name,
-		Email: u.Email,
-		Phone: u.Phone,
+		ID:           u.ID,
+		Name:         u.Name,
+		Email:        u.Email,
+		Phone:        u.Phone,
+		BaseCurrency: u.BaseCurrency,
+	}
+}
+
+func (u *User) ToAdminDTO() *UserAdminDTO {
+	return &UserAdminDTO{
+		ID:        u.ID,
+		CreatedAt: u.CreatedAt,
+		Name:      u.Name,
+		Email:     u.Email,
+		Phone:     u.Phone,
+		Activated: u.Activated,
+		Disabled:  u.Disabled,
+		Role:      u.Role.String(),
+		Version:   u.Version,
 	}
 }
 
 func (u *UserDTO) ToModel() *User {
 	return &User{
-		ID:    u.ID,
-		Name:  u.Name,
-		Email: u.Email,
-		Phone: u.Phone,
+		ID:           u.ID,
+		Name:         u.Name,
+		Email:        u.Email,
+		Phone:        u.Phone,
+		BaseCurrency: u.BaseCurrency,
 	}
 }
 
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"financas/internal/model"
+	"time"
+)
+
+type AdminRepository struct {
+	db *sql.DB
+}
+
+type AdminRepositoryInterface interface {
+	GetSystemStats() (*model.SystemStats, error)
+}
+
+func NewAdminRepository(db *sql.DB) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+func (r *AdminRepository) GetSystemStats() (*model.SystemStats, error) {
+	query := `
+	SELECT
+		(SELECT count(*) FROM users WHERE deleted = false),
+		(SELECT count(*) FROM users WHERE deleted = false AND activated = true AND disabled = false),
+		(SELECT count(*) FROM categories WHERE deleted = false),
+		(SELECT count(*) FROM transactions WHERE deleted = false),
+		(SELECT count(*) FROM goals WHERE deleted = false)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stats := &model.SystemStats{
+		GoalsByStatus: make(map[string]int),
+	}
+
+	err := r.db.QueryRowContext(ctx, query).Scan(
+		&stats.TotalUsers,
+		&stats.ActivatedUsers,
+		&stats.TotalCategories,
+		&stats.TotalTransactions,
+		&stats.TotalGoals,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	statusQuery := `
+	SELECT status, count(*)
+	FROM goals
+	WHERE deleted = false
+	GROUP BY status
+	`
+
+	rows, err := r.db.QueryContext(ctx, statusQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var status model.GoalStatus
+		var total int
+
+		err := rows.Scan(&status, &total)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.GoalsByStatus[status.String()] = total
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,413 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
+	e "financas/utils/errors"
+	"fmt"
+	"time"
+)
+
+type AssetRepository struct {
+	db *sql.DB
+}
+
+type AssetRepositoryInterface interface {
+	GetAll(name string, userID int64, f filters.Filters) ([]*model.Asset, filters.Metadata, error)
+	GetAllWithValuations(userID int64, until time.Time) ([]*model.Asset, error)
+	GetByID(id, userID int64) (*model.Asset, error)
+	Insert(asset *model.Asset) error
+	Update(asset *model.Asset) error
+	Delete(id, userID int64) error
+	GetValuations(assetID int64) ([]*model.AssetValuation, error)
+	UpsertValuation(valuation *model.AssetValuation) error
+	DeleteValuation(id, assetID int64) error
+}
+
+func NewAssetRepository(db *sql.DB) *AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+func (r *AssetRepository) GetAll(name string, userID int64, f filters.Filters) ([]*model.Asset, filters.Metadata, error) {
+	query := fmt.Sprintf(`
+	SELECT
+		count(*) OVER(),
+		a.id,
+		a.created_at,
+		a.name,
+		a.class,
+		a.currency,
+		a.version,
+		v.id,
+		v.value,
+		v.date,
+		v.created_at,
+		v.version
+	FROM assets a
+	LEFT JOIN LATERAL (
+		SELECT id, value, date, created_at, version
+		FROM asset_valuations
+		WHERE asset_id = a.id
+		ORDER BY date DESC
+		LIMIT 1
+	) v ON true
+	WHERE
+		(to_tsvector('simple', a.name) @@ plainto_tsquery('simple', $1) OR $1 = '')
+		AND a.user_id = $2
+		AND a.deleted = false
+	ORDER BY a.%s %s, a.id ASC
+	LIMIT $3 OFFSET $4
+	`, f.SortColumn(), f.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, name, userID, f.Limit(), f.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	assets := []*model.Asset{}
+
+	for rows.Next() {
+		var (
+			valuationID        sql.NullInt64
+			valuationValue     money.Money
+			valuationDate      sql.NullTime
+			valuationCreatedAt sql.NullTime
+			valuationVersion   sql.NullInt64
+		)
+
+		asset := &model.Asset{User: &model.User{ID: userID}}
+
+		err := rows.Scan(
+			&totalRecords,
+			&asset.ID,
+			&asset.CreatedAt,
+			&asset.Name,
+			&asset.Class,
+			&asset.Currency,
+			&asset.Version,
+			&valuationID,
+			&valuationValue,
+			&valuationDate,
+			&valuationCreatedAt,
+			&valuationVersion,
+		)
+		if err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		if valuationID.Valid {
+			valuation := &model.AssetValuation{
+				ID:        valuationID.Int64,
+				AssetID:   asset.ID,
+				Value:     valuationValue,
+				Date:      valuationDate.Time,
+				CreatedAt: valuationCreatedAt.Time,
+				Version:   int(valuationVersion.Int64),
+			}
+
+			asset.Valuations = []*model.AssetValuation{valuation}
+		}
+
+		assets = append(assets, asset)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metaData := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return assets, metaData, nil
+}
+
+func (r *AssetRepository) GetAllWithValuations(userID int64, until time.Time) ([]*model.Asset, error) {
+	query := `
+	SELECT
+		a.id,
+		a.created_at,
+		a.name,
+		a.class,
+		a.currency,
+		a.version,
+		v.id,
+		v.value,
+		v.date,
+		v.created_at,
+		v.version
+	FROM assets a
+	INNER JOIN asset_valuations v ON (v.asset_id = a.id)
+	WHERE
+		a.user_id = $1
+		AND a.deleted = false
+		AND v.date < $2
+	ORDER BY a.id ASC, v.date ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, until)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	assets := []*model.Asset{}
+	var current *model.Asset
+
+	for rows.Next() {
+		asset := &model.Asset{User: &model.User{ID: userID}}
+		valuation := &model.AssetValuation{}
+
+		err := rows.Scan(
+			&asset.ID,
+			&asset.CreatedAt,
+			&asset.Name,
+			&asset.Class,
+			&asset.Currency,
+			&asset.Version,
+			&valuation.ID,
+			&valuation.Value,
+			&valuation.Date,
+			&valuation.CreatedAt,
+			&valuation.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil || current.ID != asset.ID {
+			current = asset
+			assets = append(assets, current)
+		}
+
+		valuation.AssetID = current.ID
+		current.Valuations = append(current.Valuations, valuation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+func (r *AssetRepository) GetByID(id, userID int64) (*model.Asset, error) {
+	query := `
+	SELECT id, created_at, name, class, currency, version
+	FROM assets
+	WHERE id = $1 AND user_id = $2 AND deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	asset := &model.Asset{User: &model.User{ID: userID}}
+
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
+		&asset.ID,
+		&asset.CreatedAt,
+		&asset.Name,
+		&asset.Class,
+		&asset.Currency,
+		&asset.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return asset, nil
+}
+
+func (r *AssetRepository) Insert(asset *model.Asset) error {
+	query := `
+	INSERT INTO assets (user_id, name, class, currency)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, version
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, asset.User.ID, asset.Name, asset.Class, asset.Currency).Scan(
+		&asset.ID,
+		&asset.CreatedAt,
+		&asset.Version,
+	)
+}
+
+func (r *AssetRepository) Update(asset *model.Asset) error {
+	query := `
+	UPDATE assets
+	SET
+		name = $1,
+		class = $2,
+		currency = $3,
+		version = version + 1
+	WHERE
+		id = $4
+		AND user_id = $5
+		AND deleted = false
+		AND version = $6
+	RETURNING created_at, version
+	`
+
+	args := []any{
+		asset.Name,
+		asset.Class,
+		asset.Currency,
+		asset.ID,
+		asset.User.ID,
+		asset.Version,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&asset.CreatedAt, &asset.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrEditConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *AssetRepository) Delete(id, userID int64) error {
+	query := `
+	UPDATE assets
+	SET
+		deleted = true
+	WHERE
+		id = $1
+		AND user_id = $2
+		AND deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *AssetRepository) GetValuations(assetID int64) ([]*model.AssetValuation, error) {
+	query := `
+	SELECT id, value, date, created_at, version
+	FROM asset_valuations
+	WHERE asset_id = $1
+	ORDER BY date DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	valuations := []*model.AssetValuation{}
+
+	for rows.Next() {
+		valuation := &model.AssetValuation{AssetID: assetID}
+
+		err := rows.Scan(
+			&valuation.ID,
+			&valuation.Value,
+			&valuation.Date,
+			&valuation.CreatedAt,
+			&valuation.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		valuations = append(valuations, valuation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return valuations, nil
+}
+
+func (r *AssetRepository) UpsertValuation(valuation *model.AssetValuation) error {
+	query := `
+	INSERT INTO asset_valuations (asset_id, value, date)
+	VALUES ($1, $2, $3)
+	ON CONFLICT ON CONSTRAINT unique_asset_valuation_date
+	DO UPDATE SET
+		value = EXCLUDED.value,
+		version = asset_valuations.version + 1
+	RETURNING id, created_at, version
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, valuation.AssetID, valuation.Value, valuation.Date).Scan(
+		&valuation.ID,
+		&valuation.CreatedAt,
+		&valuation.Version,
+	)
+}
+
+func (r *AssetRepository) DeleteValuation(id, assetID int64) error {
+	query := `
+	DELETE FROM asset_valuations
+	WHERE id = $1 AND asset_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, assetID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	e "financas/utils/errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ExchangeRateRepository struct {
+	db *sql.DB
+}
+
+type ExchangeRateRepositoryInterface interface {
+	GetAll(currency string, userID int64, f filters.Filters) ([]*model.ExchangeRate, filters.Metadata, error)
+	GetAllForUser(userID int64) ([]*model.ExchangeRate, error)
+	GetByID(id, userID int64) (*model.ExchangeRate, error)
+	Insert(rate *model.ExchangeRate) error
+	Upsert(rate *model.ExchangeRate, tx *sql.Tx) error
+	Update(rate *model.ExchangeRate) error
+	Delete(id, userID int64) error
+}
+
+func NewExchangeRateRepository(db *sql.DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+func (r *ExchangeRateRepository) GetAll(currency string, userID int64, f filters.Filters) ([]*model.ExchangeRate, filters.Metadata, error) {
+	query := fmt.Sprintf(`
+	SELECT 
+		count(*) OVER(),
+		id,
+		user_id,
+		from_currency,
+		to_currency,
+		rate,
+		date,
+		created_at,
+		version
+	FROM exchange_rates
+	WHERE 
+		user_id = $1
+		AND ($2 = '' OR from_currency = $2 OR to_currency = $2)
+	ORDER BY %s %s, id ASC
+	LIMIT $3 OFFSET $4
+	`, f.SortColumn(), f.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, currency, f.Limit(), f.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	rates := []*model.ExchangeRate{}
+
+	for rows.Next() {
+		rate := &model.ExchangeRate{User: &model.User{}}
+		err := rows.Scan(
+			&totalRecords,
+			&rate.ID,
+			&rate.User.ID,
+			&rate.FromCurrency,
+			&rate.ToCurrency,
+			&rate.Rate,
+			&rate.Date,
+			&rate.CreatedAt,
+			&rate.Version,
+		)
+		if err != nil {
+			return nil, filters.Metadata{}, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metaData := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return rates, metaData, nil
+}
+
+func (r *ExchangeRateRepository) GetAllForUser(userID int64) ([]*model.ExchangeRate, error) {
+	query := `
+	SELECT 
+		id,
+		user_id,
+		from_currency,
+		to_currency,
+		rate,
+		date,
+		created_at,
+		version
+	FROM exchange_rates
+	WHERE user_id = $1
+	ORDER BY date ASC, id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rates := []*model.ExchangeRate{}
+
+	for rows.Next() {
+		rate := &model.ExchangeRate{User: &model.User{}}
+		err := rows.Scan(
+			&rate.ID,
+			&rate.User.ID,
+			&rate.FromCurrency,
+			&rate.ToCurrency,
+			&rate.Rate,
+			&rate.Date,
+			&rate.CreatedAt,
+			&rate.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *ExchangeRateRepository) GetByID(id, userID int64) (*model.ExchangeRate, error) {
+	query := `
+	SELECT 
+		id,
+		user_id,
+		from_currency,
+		to_currency,
+		rate,
+		date,
+		created_at,
+		version
+	FROM exchange_rates
+	WHERE id = $1 AND user_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rate := &model.ExchangeRate{User: &model.User{}}
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
+		&rate.ID,
+		&rate.User.ID,
+		&rate.FromCurrency,
+		&rate.ToCurrency,
+		&rate.Rate,
+		&rate.Date,
+		&rate.CreatedAt,
+		&rate.Version,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return rate, nil
+}
+
+func (r *ExchangeRateRepository) Insert(rate *model.ExchangeRate) error {
+	query := `
+	INSERT INTO exchange_rates (user_id, from_currency, to_currency, rate, date)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{
+		rate.User.ID,
+		rate.FromCurrency,
+		rate.ToCurrency,
+		rate.Rate,
+		rate.Date,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&rate.ID,
+		&rate.CreatedAt,
+		&rate.Version,
+	)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_user_exchange_rate":
+				return e.ErrDuplicateExchangeRate
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepository) Upsert(rate *model.ExchangeRate, tx *sql.Tx) error {
+	query := `
+	INSERT INTO exchange_rates (user_id, from_currency, to_currency, rate, date)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT ON CONSTRAINT unique_user_exchange_rate
+	DO UPDATE SET 
+		rate = EXCLUDED.rate,
+		version = exchange_rates.version + 1
+	RETURNING id, created_at, version
+	`
+
+	args := []any{
+		rate.User.ID,
+		rate.FromCurrency,
+		rate.ToCurrency,
+		rate.Rate,
+		rate.Date,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return tx.QueryRowContext(ctx, query, args...).Scan(
+		&rate.ID,
+		&rate.CreatedAt,
+		&rate.Version,
+	)
+}
+
+func (r *ExchangeRateRepository) Update(rate *model.ExchangeRate) error {
+	query := `
+	UPDATE exchange_rates
+	SET
+		from_currency = $1,
+		to_currency = $2,
+		rate = $3,
+		date = $4,
+		version = version + 1
+	WHERE
+		id = $5
+		AND user_id = $6
+		AND version = $7
+	RETURNING version
+	`
+
+	args := []any{
+		rate.FromCurrency,
+		rate.ToCurrency,
+		rate.Rate,
+		rate.Date,
+		rate.ID,
+		rate.User.ID,
+		rate.Version,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&rate.Version)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrEditConflict
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_user_exchange_rate":
+				return e.ErrDuplicateExchangeRate
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepository) Delete(id, userID int64) error {
+	query := `
+	DELETE FROM exchange_rates
+	WHERE id = $1 AND user_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -20,8 +20,15 @@ type GoalRepository struct {
 type GoalRepositoryInterface interface {
 	GetAllByUserId(name string, userID int64, f filters.Filters) ([]*model.Goal, filters.Metadata, error)
 	GetById(id, idUser int64) (*model.Goal, error)
+	GetAccessible(id, userID int64) (*model.Goal, error)
+	GetForUpdate(id int64, tx *sql.Tx) (*model.Goal, error)
+	GetShared(userID int64) ([]*model.Goal, error)
+	GetActiveByUserId(userID int64) ([]*model.Goal, error)
+	GetContributionTotals(userID int64, since time.Time) (map[int64]*model.ContributionTotal, error)
+	InsertStatusChange(change *model.GoalStatusChange, tx *sql.Tx) error
+	GetStatusHistory(goalID, userID int64) ([]*model.GoalStatusChange, error)
 	Create(goal *model.Goal) error
-	Update(goal *model.Goal, idUser int64) error
+	Update(goal *model.Goal, idUser int64, tx *sql.Tx) error
 	Delete(id, idUser int64) error
 }
 
@@ -40,7 +47,9 @@ func (r *GoalRepository) GetAllByUserId(name string, userID int64, f filters.Fil
 		goals.deadline, 
 		goals.amount, 
 		goals.current,
+		goals.currency,
 		goals.status,
+		goals.annual_rate,
 		goals.version,
 		goals.	created_at,
 		goals.deleted,
@@ -90,7 +99,9 @@ func (r *GoalRepository) GetAllByUserId(name string, userID int64, f filters.Fil
 			&goal.Deadline,
 			&goal.Amount,
 			&goal.Current,
+			&goal.Currency,
 			&goal.Status,
+			&goal.AnnualRate,
 			&goal.Version,
 			&goal.CreatedAt,
 			&goal.Deleted,
@@ -118,8 +129,7 @@ func (r *GoalRepository) GetAllByUserId(name string, userID int64, f filters.Fil
 	return goals, metaData, nil
 }
 
-func (r *GoalRepository) GetById(id, idUser int64) (*model.Goal, error) {
-	query := `
+const goalSelect = `
 	SELECT
 		goals.id,
 		goals.name,
@@ -129,7 +139,9 @@ func (r *GoalRepository) GetById(id, idUser int64) (*model.Goal, error) {
 		goals.deadline,
 		goals.amount,
 		goals.current,
+		goals.currency,
 		goals.status,
+		goals.annual_rate,
 		goals.version,
 		goals.created_at,
 		goals.deleted,
@@ -142,18 +154,119 @@ func (r *GoalRepository) GetById(id, idUser int64) (*model.Goal, error) {
 		u.version as u_version
 	from goals
 	inner join users u on (goals.user_id = u.id)
+`
+
+func (r *GoalRepository) GetById(id, idUser int64) (*model.Goal, error) {
+	query := goalSelect + `
 	where 
 		goals.id = $1 
 		and goals.user_id = $2 
 		and goals.deleted = false
 	`
 
-	goal := &model.Goal{}
-	goal.User = &model.User{}
+	return r.getOne(query, id, idUser)
+}
+
+func (r *GoalRepository) GetAccessible(id, userID int64) (*model.Goal, error) {
+	query := goalSelect + `
+	where
+		goals.id = $1
+		and goals.deleted = false
+		and (
+			goals.user_id = $2
+			or exists (
+				select 1 from goal_members m
+				where m.goal_id = goals.id and m.user_id = $2 and m.status = $3
+			)
+		)
+	`
+
+	return r.getOne(query, id, userID, model.GoalMemberAccepted)
+}
+
+func (r *GoalRepository) GetShared(userID int64) ([]*model.Goal, error) {
+	query := goalSelect + `
+	inner join goal_members m on (m.goal_id = goals.id)
+	where
+		m.user_id = $1
+		and m.status = $2
+		and goals.deleted = false
+	order by goals.deadline asc, goals.id asc
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, model.GoalMemberAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	goals := []*model.Goal{}
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		goals = append(goals, goal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+}
+
+func (r *GoalRepository) GetForUpdate(id int64, tx *sql.Tx) (*model.Goal, error) {
+	query := goalSelect + `
+	where
+		goals.id = $1
+		and goals.deleted = false
+	for update of goals
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	goal, err := scanGoal(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return goal, nil
+}
 
+func (r *GoalRepository) getOne(query string, args ...any) (*model.Goal, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
-	err := r.db.QueryRowContext(ctx, query, id, idUser).Scan(
+
+	goal, err := scanGoal(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return goal, nil
+}
+
+func scanGoal(row interface{ Scan(dest ...any) error }) (*model.Goal, error) {
+	goal := &model.Goal{}
+	goal.User = &model.User{}
+
+	err := row.Scan(
 		&goal.ID,
 		&goal.Name,
 		&goal.Description,
@@ -162,7 +275,9 @@ func (r *GoalRepository) GetById(id, idUser int64) (*model.Goal, error) {
 		&goal.Deadline,
 		&goal.Amount,
 		&goal.Current,
+		&goal.Currency,
 		&goal.Status,
+		&goal.AnnualRate,
 		&goal.Version,
 		&goal.CreatedAt,
 		&goal.Deleted,
@@ -174,17 +289,84 @@ func (r *GoalRepository) GetById(id, idUser int64) (*model.Goal, error) {
 		&goal.User.Activated,
 		&goal.User.Version,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+func (r *GoalRepository) GetActiveByUserId(userID int64) ([]*model.Goal, error) {
+	query := `
+	SELECT
+		id,
+		name,
+		description,
+		color,
+		user_id,
+		deadline,
+		amount,
+		current,
+		currency,
+		status,
+		annual_rate,
+		version,
+		created_at,
+		deleted
+	FROM goals
+	WHERE
+		user_id = $1
+		AND deleted = false
+		AND status IN ($2, $3)
+		AND deadline > NOW()
+	ORDER BY deadline ASC, id ASC
+	`
 
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, model.GoalStatusPending, model.GoalStatusInProgress)
 	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return nil, e.ErrRecordNotFound
-		default:
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	goals := []*model.Goal{}
+
+	for rows.Next() {
+		goal := &model.Goal{
+			User: &model.User{},
+		}
+
+		err := rows.Scan(
+			&goal.ID,
+			&goal.Name,
+			&goal.Description,
+			&goal.Color,
+			&goal.User.ID,
+			&goal.Deadline,
+			&goal.Amount,
+			&goal.Current,
+			&goal.Currency,
+			&goal.Status,
+			&goal.AnnualRate,
+			&goal.Version,
+			&goal.CreatedAt,
+			&goal.Deleted,
+		)
+		if err != nil {
 			return nil, err
 		}
+
+		goals = append(goals, goal)
 	}
 
-	return goal, nil
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goals, nil
 }
 
 func (r *GoalRepository) Create(goal *model.Goal) error {
@@ -196,7 +378,9 @@ func (r *GoalRepository) Create(goal *model.Goal) error {
 		user_id,
 		deadline, 
 		amount, 
-		current
+		current,
+		currency,
+		annual_rate
 		)
 	VALUES (
 		$1, 
@@ -205,7 +389,9 @@ func (r *GoalRepository) Create(goal *model.Goal) error {
 		$4, 
 		$5, 
 		$6, 
-		$7
+		$7,
+		$8,
+		$9
 		)
 	RETURNING 
 		id, 
@@ -221,6 +407,8 @@ func (r *GoalRepository) Create(goal *model.Goal) error {
 		goal.Deadline,
 		goal.Amount,
 		goal.Current,
+		goal.Currency,
+		goal.AnnualRate,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -247,7 +435,7 @@ func (r *GoalRepository) Create(goal *model.Goal) error {
 	return nil
 }
 
-func (r *GoalRepository) Update(goal *model.Goal, idUser int64) error {
+func (r *GoalRepository) Update(goal *model.Goal, idUser int64, tx *sql.Tx) error {
 	query := `
 	UPDATE goals
 	SET	
@@ -258,11 +446,13 @@ func (r *GoalRepository) Update(goal *model.Goal, idUser int64) error {
 		amount = $5, 
 		current = $6,
 		status = $7,
+		currency = $8,
+		annual_rate = $9,
 		version = version + 1
 	WHERE 
-		id = $8 
-		AND version = $9
-		AND user_id = $10
+		id = $10 
+		AND version = $11
+		AND user_id = $12
 		AND deleted = false
 	RETURNING version
 	`
@@ -275,6 +465,8 @@ func (r *GoalRepository) Update(goal *model.Goal, idUser int64) error {
 		goal.Amount,
 		goal.Current,
 		goal.Status,
+		goal.Currency,
+		goal.AnnualRate,
 		goal.ID,
 		goal.Version,
 		idUser,
@@ -283,7 +475,7 @@ func (r *GoalRepository) Update(goal *model.Goal, idUser int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&goal.Version)
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&goal.Version)
 
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
@@ -293,6 +485,9 @@ func (r *GoalRepository) Update(goal *model.Goal, idUser int64) error {
 			}
 		}
 
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrEditConflict
+		}
 		return err
 	}
 
@@ -329,3 +524,157 @@ func (r *GoalRepository) Delete(id, idUser int64) error {
 
 	return nil
 }
+
+func (r *GoalRepository) GetContributionTotals(userID int64, since time.Time) (map[int64]*model.ContributionTotal, error) {
+	query := `
+	SELECT
+		gp.goal_id,
+		MIN(gp.date),
+		COALESCE(SUM(gp.amount), 0)
+	FROM goal_progress gp
+	INNER JOIN goals g ON gp.goal_id = g.id
+	WHERE
+		g.user_id = $1
+		AND g.deleted = false
+		AND gp.deleted = false
+		AND gp.date >= $2
+	GROUP BY gp.goal_id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	totals := make(map[int64]*model.ContributionTotal)
+
+	for rows.Next() {
+		var goalID int64
+		total := &model.ContributionTotal{}
+
+		if err := rows.Scan(&goalID, &total.First, &total.Amount); err != nil {
+			return nil, err
+		}
+
+		totals[goalID] = total
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func (r *GoalRepository) InsertStatusChange(change *model.GoalStatusChange, tx *sql.Tx) error {
+	query := `
+	INSERT INTO goal_status_history (
+		goal_id,
+		from_status,
+		to_status,
+		action,
+		changed_by,
+		reason,
+		previous_deadline,
+		new_deadline
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id, created_at
+	`
+
+	args := []any{
+		change.GoalID,
+		change.From,
+		change.To,
+		change.Action,
+		change.ChangedBy,
+		change.Reason,
+		change.PreviousDeadline,
+		change.NewDeadline,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return tx.QueryRowContext(ctx, query, args...).Scan(&change.ID, &change.CreatedAt)
+}
+
+func (r *GoalRepository) GetStatusHistory(goalID, userID int64) ([]*model.GoalStatusChange, error) {
+	query := `
+	SELECT
+		h.id,
+		h.goal_id,
+		h.from_status,
+		h.to_status,
+		h.action,
+		h.changed_by,
+		h.reason,
+		h.previous_deadline,
+		h.new_deadline,
+		h.created_at
+	FROM goal_status_history h
+	INNER JOIN goals g ON h.goal_id = g.id
+	WHERE
+		h.goal_id = $1
+		AND g.user_id = $2
+		AND g.deleted = false
+	ORDER BY h.created_at DESC, h.id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	changes := []*model.GoalStatusChange{}
+
+	for rows.Next() {
+		var changedBy sql.NullInt64
+		var previousDeadline, newDeadline sql.NullTime
+		change := &model.GoalStatusChange{}
+
+		err := rows.Scan(
+			&change.ID,
+			&change.GoalID,
+			&change.From,
+			&change.To,
+			&change.Action,
+			&changedBy,
+			&change.Reason,
+			&previousDeadline,
+			&newDeadline,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if changedBy.Valid {
+			change.ChangedBy = &changedBy.Int64
+		}
+		if previousDeadline.Valid {
+			change.PreviousDeadline = &previousDeadline.Time
+		}
+		if newDeadline.Valid {
+			change.NewDeadline = &newDeadline.Time
+		}
+
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
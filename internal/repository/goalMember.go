@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	e "financas/utils/errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type GoalMemberRepository struct {
+	db *sql.DB
+}
+
+type GoalMemberRepositoryInterface interface {
+	GetByGoalID(goalID int64) ([]*model.GoalMember, error)
+	GetByID(id int64) (*model.GoalMember, error)
+	GetInvites(userID int64) ([]*model.GoalMember, error)
+	Insert(member *model.GoalMember) error
+	UpdateStatus(member *model.GoalMember) error
+	Delete(id int64) error
+	GetContributors(goalID int64) ([]*model.GoalContributor, error)
+}
+
+func NewGoalMemberRepository(db *sql.DB) *GoalMemberRepository {
+	return &GoalMemberRepository{db: db}
+}
+
+const goalMemberSelect = `
+	SELECT
+		m.id,
+		m.goal_id,
+		g.name,
+		g.user_id,
+		m.user_id,
+		u.name,
+		u.email,
+		m.invited_by,
+		m.status,
+		m.created_at,
+		m.responded_at
+	FROM goal_members m
+	INNER JOIN goals g ON m.goal_id = g.id
+	INNER JOIN users u ON m.user_id = u.id
+`
+
+func (r *GoalMemberRepository) GetByGoalID(goalID int64) ([]*model.GoalMember, error) {
+	query := goalMemberSelect + `
+	WHERE m.goal_id = $1 AND g.deleted = false
+	ORDER BY m.created_at ASC, m.id ASC
+	`
+
+	return r.getAll(query, goalID)
+}
+
+func (r *GoalMemberRepository) GetInvites(userID int64) ([]*model.GoalMember, error) {
+	query := goalMemberSelect + `
+	WHERE m.user_id = $1 AND m.status = $2 AND g.deleted = false
+	ORDER BY m.created_at DESC, m.id DESC
+	`
+
+	return r.getAll(query, userID, model.GoalMemberPending)
+}
+
+func (r *GoalMemberRepository) GetByID(id int64) (*model.GoalMember, error) {
+	query := goalMemberSelect + `
+	WHERE m.id = $1 AND g.deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	member, err := scanGoalMember(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return member, nil
+}
+
+func (r *GoalMemberRepository) Insert(member *model.GoalMember) error {
+	query := `
+	INSERT INTO goal_members (goal_id, user_id, invited_by, status)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT ON CONSTRAINT unique_goal_member
+	DO UPDATE SET
+		invited_by = EXCLUDED.invited_by,
+		status = EXCLUDED.status,
+		created_at = NOW(),
+		responded_at = NULL
+	WHERE goal_members.status = $5
+	RETURNING id, created_at
+	`
+
+	args := []any{
+		member.Goal.ID,
+		member.User.ID,
+		member.InvitedBy,
+		member.Status,
+		model.GoalMemberDeclined,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&member.ID, &member.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_goal_member":
+				return e.ErrDuplicateGoalMember
+			}
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrDuplicateGoalMember
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *GoalMemberRepository) UpdateStatus(member *model.GoalMember) error {
+	query := `
+	UPDATE goal_members
+	SET
+		status = $1,
+		responded_at = NOW()
+	WHERE id = $2 AND status = $3
+	RETURNING responded_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, member.Status, member.ID, model.GoalMemberPending).Scan(&member.RespondedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrEditConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *GoalMemberRepository) Delete(id int64) error {
+	query := `
+	DELETE FROM goal_members
+	WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *GoalMemberRepository) GetContributors(goalID int64) ([]*model.GoalContributor, error) {
+	query := `
+	WITH participants AS (
+		SELECT user_id FROM goals WHERE id = $1
+		UNION
+		SELECT user_id FROM goal_members WHERE goal_id = $1 AND status = $2
+		UNION
+		SELECT user_id FROM goal_progress WHERE goal_id = $1 AND deleted = false
+	)
+	SELECT
+		p.user_id,
+		u.name,
+		p.user_id = g.user_id,
+		count(gp.id),
+		COALESCE(SUM(gp.amount), 0)
+	FROM participants p
+	INNER JOIN users u ON u.id = p.user_id
+	INNER JOIN goals g ON g.id = $1
+	LEFT JOIN goal_progress gp ON gp.goal_id = $1 AND gp.user_id = p.user_id AND gp.deleted = false
+	GROUP BY p.user_id, u.name, g.user_id
+	ORDER BY 5 DESC, p.user_id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, goalID, model.GoalMemberAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	contributors := []*model.GoalContributor{}
+	for rows.Next() {
+		contributor := &model.GoalContributor{}
+
+		err := rows.Scan(
+			&contributor.UserID,
+			&contributor.Name,
+			&contributor.Owner,
+			&contributor.Entries,
+			&contributor.Total,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		contributors = append(contributors, contributor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contributors, nil
+}
+
+func (r *GoalMemberRepository) getAll(query string, args ...any) ([]*model.GoalMember, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	members := []*model.GoalMember{}
+	for rows.Next() {
+		member, err := scanGoalMember(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func scanGoalMember(row interface{ Scan(dest ...any) error }) (*model.GoalMember, error) {
+	member := &model.GoalMember{
+		Goal: &model.Goal{User: &model.User{}},
+		User: &model.User{},
+	}
+
+	err := row.Scan(
+		&member.ID,
+		&member.Goal.ID,
+		&member.Goal.Name,
+		&member.Goal.User.ID,
+		&member.User.ID,
+		&member.User.Name,
+		&member.User.Email,
+		&member.InvitedBy,
+		&member.Status,
+		&member.CreatedAt,
+		&member.RespondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
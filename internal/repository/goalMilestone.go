@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	e "financas/utils/errors"
+	"time"
+)
+
+type GoalMilestoneRepository struct {
+	db *sql.DB
+}
+
+type GoalMilestoneRepositoryInterface interface {
+	GetByGoalID(goalID, userID int64) ([]*model.GoalMilestone, error)
+	GetByID(id, userID int64) (*model.GoalMilestone, error)
+	Insert(milestone *model.GoalMilestone) error
+	Update(milestone *model.GoalMilestone, userID int64) error
+	Delete(id, userID int64) error
+	UpdateReached(milestone *model.GoalMilestone, tx *sql.Tx) error
+	GetEvents(goalID, userID int64) ([]*model.GoalEvent, error)
+	InsertEvent(event *model.GoalEvent, tx *sql.Tx) error
+}
+
+func NewGoalMilestoneRepository(db *sql.DB) *GoalMilestoneRepository {
+	return &GoalMilestoneRepository{db: db}
+}
+
+const goalMilestoneSelect = `
+	SELECT
+		m.id,
+		m.goal_id,
+		g.amount,
+		m.name,
+		m.kind,
+		m.amount,
+		m.percentage,
+		m.target_date,
+		m.reached_at,
+		m.created_at,
+		m.version
+	FROM goal_milestones m
+	INNER JOIN goals g ON m.goal_id = g.id
+`
+
+func (r *GoalMilestoneRepository) GetByGoalID(goalID, userID int64) ([]*model.GoalMilestone, error) {
+	query := goalMilestoneSelect + `
+	WHERE
+		m.goal_id = $1
+		AND g.user_id = $2
+		AND m.deleted = false
+		AND g.deleted = false
+	ORDER BY m.target_date ASC NULLS LAST, m.id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	milestones := []*model.GoalMilestone{}
+	for rows.Next() {
+		milestone, err := scanGoalMilestone(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		milestones = append(milestones, milestone)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return milestones, nil
+}
+
+func (r *GoalMilestoneRepository) GetByID(id, userID int64) (*model.GoalMilestone, error) {
+	query := goalMilestoneSelect + `
+	WHERE
+		m.id = $1
+		AND g.user_id = $2
+		AND m.deleted = false
+		AND g.deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	milestone, err := scanGoalMilestone(r.db.QueryRowContext(ctx, query, id, userID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return milestone, nil
+}
+
+func (r *GoalMilestoneRepository) Insert(milestone *model.GoalMilestone) error {
+	query := `
+	INSERT INTO goal_milestones (goal_id, name, kind, amount, percentage, target_date)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{
+		milestone.Goal.ID,
+		milestone.Name,
+		milestone.Kind,
+		milestone.Amount,
+		milestone.Percentage,
+		milestone.TargetDate,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, args...).Scan(
+		&milestone.ID,
+		&milestone.CreatedAt,
+		&milestone.Version,
+	)
+}
+
+func (r *GoalMilestoneRepository) Update(milestone *model.GoalMilestone, userID int64) error {
+	query := `
+	UPDATE goal_milestones m
+	SET
+		name = $1,
+		kind = $2,
+		amount = $3,
+		percentage = $4,
+		target_date = $5,
+		version = m.version + 1
+	FROM goals g
+	WHERE
+		m.goal_id = g.id
+		AND m.id = $6
+		AND g.user_id = $7
+		AND m.deleted = false
+		AND m.version = $8
+	RETURNING m.version
+	`
+
+	args := []any{
+		milestone.Name,
+		milestone.Kind,
+		milestone.Amount,
+		milestone.Percentage,
+		milestone.TargetDate,
+		milestone.ID,
+		userID,
+		milestone.Version,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&milestone.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return e.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *GoalMilestoneRepository) Delete(id, userID int64) error {
+	query := `
+	UPDATE goal_milestones
+	SET deleted = true
+	WHERE
+		id = $1
+		AND deleted = false
+		AND goal_id IN (
+			SELECT id FROM goals WHERE user_id = $2
+		)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *GoalMilestoneRepository) UpdateReached(milestone *model.GoalMilestone, tx *sql.Tx) error {
+	query := `
+	UPDATE goal_milestones
+	SET reached_at = $1, version = version + 1
+	WHERE id = $2 AND deleted = false AND version = $3
+	RETURNING version
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := tx.QueryRowContext(ctx, query, milestone.ReachedAt, milestone.ID, milestone.Version).Scan(&milestone.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return e.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *GoalMilestoneRepository) GetEvents(goalID, userID int64) ([]*model.GoalEvent, error) {
+	query := `
+	SELECT
+		ev.id,
+		ev.goal_id,
+		ev.type,
+		ev.milestone_id,
+		ev.amount,
+		ev.description,
+		ev.created_at
+	FROM goal_events ev
+	INNER JOIN goals g ON ev.goal_id = g.id
+	WHERE
+		ev.goal_id = $1
+		AND g.user_id = $2
+		AND g.deleted = false
+	ORDER BY ev.created_at DESC, ev.id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := []*model.GoalEvent{}
+	for rows.Next() {
+		var milestoneID sql.NullInt64
+		event := &model.GoalEvent{}
+
+		err := rows.Scan(
+			&event.ID,
+			&event.GoalID,
+			&event.Type,
+			&milestoneID,
+			&event.Amount,
+			&event.Description,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if milestoneID.Valid {
+			event.MilestoneID = &milestoneID.Int64
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *GoalMilestoneRepository) InsertEvent(event *model.GoalEvent, tx *sql.Tx) error {
+	query := `
+	INSERT INTO goal_events (goal_id, type, milestone_id, amount, description)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at
+	`
+
+	args := []any{
+		event.GoalID,
+		event.Type,
+		event.MilestoneID,
+		event.Amount,
+		event.Description,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return tx.QueryRowContext(ctx, query, args...).Scan(&event.ID, &event.CreatedAt)
+}
+
+type goalMilestoneScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanGoalMilestone(row goalMilestoneScanner) (*model.GoalMilestone, error) {
+	var targetDate, reachedAt sql.NullTime
+	milestone := &model.GoalMilestone{Goal: &model.Goal{}}
+
+	err := row.Scan(
+		&milestone.ID,
+		&milestone.Goal.ID,
+		&milestone.Goal.Amount,
+		&milestone.Name,
+		&milestone.Kind,
+		&milestone.Amount,
+		&milestone.Percentage,
+		&targetDate,
+		&reachedAt,
+		&milestone.CreatedAt,
+		&milestone.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetDate.Valid {
+		milestone.TargetDate = &targetDate.Time
+	}
+	if reachedAt.Valid {
+		milestone.ReachedAt = &reachedAt.Time
+	}
+
+	return milestone, nil
+}
@@ -5,8 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
 	e "financas/utils/errors"
+	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type GoalProgressRepository struct {
@@ -15,41 +20,49 @@ type GoalProgressRepository struct {
 
 type GoalProgressRepositoryInterface interface {
 	GetGoalProgressIDGoal(userID, goalID int64) ([]*model.GoalProgress, error)
+	GetAllByGoalID(userID, goalID int64, filter model.GoalProgressFilter, f filters.Filters) ([]*model.GoalProgress, filters.Metadata, error)
+	GetMonthlySummary(userID, goalID int64, startDate, endDate *time.Time) ([]*model.GoalProgressMonth, error)
 	GetGoalProgressByID(userID, gPID int64) (*model.GoalProgress, error)
-	Insert(gP *model.GoalProgress) error
-	Update(gP *model.GoalProgress, userID int64) error
-	Delete(goalProgressID, userID int64) error
+	GetByTransactionID(userID, transactionID int64) (*model.GoalProgress, error)
+	Insert(gP *model.GoalProgress, tx *sql.Tx) error
+	GetTotal(goalID int64, tx *sql.Tx) (money.Money, error)
+	Update(gP *model.GoalProgress, userID int64, tx *sql.Tx) error
+	Delete(goalProgressID, userID int64, tx *sql.Tx) error
 }
 
 func NewGoalProgressRepository(db *sql.DB) *GoalProgressRepository {
 	return &GoalProgressRepository{db: db}
 }
 
-func (r *GoalProgressRepository) GetGoalProgressIDGoal(
-	userID, goalID int64,
-) ([]*model.GoalProgress, error) {
-	query := `
+const goalProgressSelect = `
 	SELECT
 		gp.id,
+		gp.kind,
 		gp.amount,
+		gp.reason,
 		gp.date,
 		gp.version,
 		gp.created_at,
 		gp.deleted,
-		
-		g.id AS g_id, 
-		g.name AS g_name, 
-		g.description AS g_description,  
+		gp.transaction_id,
+		gp.transaction_created,
+		gp.user_id,
+		c.name AS c_name,
+
+		g.id AS g_id,
+		g.name AS g_name,
+		g.description AS g_description,
+		g.color AS g_color,
 		g.user_id AS g_user_id,
-		g.deadline AS g_deadline, 
-		g.amount AS g_amount, 
+		g.deadline AS g_deadline,
+		g.amount AS g_amount,
 		g.current AS g_current,
+		g.currency AS g_currency,
 		g.status AS g_status,
 		g.version AS g_version,
 		g.created_at AS g_created_at,
 		g.deleted AS g_deleted,
 
-		u.id AS u_id,
 		u.name AS u_name,
 		u.phone AS u_phone,
 		u.email AS u_email,
@@ -57,17 +70,23 @@ func (r *GoalProgressRepository) GetGoalProgressIDGoal(
 		u.activated AS u_activated,
 		u.version AS u_version,
 		u.created_at AS u_created_at
-		
 	FROM
 		goal_progress gp
 	INNER JOIN goals g ON gp.goal_id = g.id
 	INNER JOIN users u ON g.user_id = u.id
+	INNER JOIN users c ON gp.user_id = c.id
+`
+
+func (r *GoalProgressRepository) GetGoalProgressIDGoal(
+	userID, goalID int64,
+) ([]*model.GoalProgress, error) {
+	query := goalProgressSelect + `
 	WHERE
 		g.user_id = $1
 		AND gp.deleted = FALSE
-		AND goals.id = $2
-	ORDER BY gp.created_at DESC;
-`
+		AND g.id = $2
+	ORDER BY gp.date DESC, gp.id DESC
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -80,131 +99,229 @@ func (r *GoalProgressRepository) GetGoalProgressIDGoal(
 
 	defer rows.Close()
 
-	var gPs []*model.GoalProgress
+	gPs := []*model.GoalProgress{}
 	for rows.Next() {
-		gP := &model.GoalProgress{
-			Goal: &model.Goal{
-				User: &model.User{},
-			},
+		gP, err := scanGoalProgress(rows)
+		if err != nil {
+			return nil, err
 		}
 
+		gPs = append(gPs, gP)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return gPs, nil
+}
+
+func (r *GoalProgressRepository) GetAllByGoalID(
+	userID, goalID int64,
+	filter model.GoalProgressFilter,
+	f filters.Filters,
+) ([]*model.GoalProgress, filters.Metadata, error) {
+	query := fmt.Sprintf(`
+	SELECT count(*) OVER(), p.* FROM (%s
+	WHERE
+		g.user_id = $1
+		AND gp.deleted = FALSE
+		AND g.id = $2
+		AND ($3 = 0 OR gp.kind = $3)
+		AND ($4::timestamp IS NULL OR gp.date >= $4::timestamp)
+		AND ($5::timestamp IS NULL OR gp.date <= $5::timestamp)
+		AND ($6::numeric IS NULL OR gp.amount >= $6::numeric)
+		AND ($7::numeric IS NULL OR gp.amount <= $7::numeric)
+	) p
+	ORDER BY p.%s %s, p.id ASC
+	LIMIT $8 OFFSET $9
+	`, goalProgressSelect, f.SortColumn(), f.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	start := sql.NullTime{}
+	if filter.StartDate != nil {
+		start.Valid = true
+		start.Time = *filter.StartDate
+	}
+
+	end := sql.NullTime{}
+	if filter.EndDate != nil {
+		end.Valid = true
+		end.Time = filter.EndDate.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+	}
+
+	args := []any{
+		userID,
+		goalID,
+		filter.Kind,
+		start,
+		end,
+		filter.MinAmount,
+		filter.MaxAmount,
+		f.Limit(),
+		f.Offset(),
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	gPs := []*model.GoalProgress{}
+
+	for rows.Next() {
+		gP, err := scanGoalProgress(prefixedScanner{rows, &totalRecords})
+		if err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		gPs = append(gPs, gP)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metadata := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return gPs, metadata, nil
+}
+
+func (r *GoalProgressRepository) GetMonthlySummary(
+	userID, goalID int64,
+	startDate, endDate *time.Time,
+) ([]*model.GoalProgressMonth, error) {
+	query := `
+	SELECT month, contributions, withdrawals, net, entries, balance
+	FROM (
+		SELECT
+			date_trunc('month', gp.date) AS month,
+			COALESCE(SUM(gp.amount) FILTER (WHERE gp.amount > 0), 0) AS contributions,
+			COALESCE(-SUM(gp.amount) FILTER (WHERE gp.amount < 0), 0) AS withdrawals,
+			SUM(gp.amount) AS net,
+			count(*) AS entries,
+			SUM(SUM(gp.amount)) OVER (ORDER BY date_trunc('month', gp.date)) AS balance
+		FROM goal_progress gp
+		INNER JOIN goals g ON gp.goal_id = g.id
+		WHERE
+			g.user_id = $1
+			AND g.id = $2
+			AND gp.deleted = FALSE
+			AND ($4::timestamp IS NULL OR gp.date <= $4::timestamp)
+		GROUP BY 1
+	) m
+	WHERE $3::timestamp IS NULL OR month >= date_trunc('month', $3::timestamp)
+	ORDER BY month ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	start := sql.NullTime{}
+	if startDate != nil {
+		start.Valid = true
+		start.Time = *startDate
+	}
+
+	end := sql.NullTime{}
+	if endDate != nil {
+		end.Valid = true
+		end.Time = endDate.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, userID, goalID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	months := []*model.GoalProgressMonth{}
+
+	for rows.Next() {
+		var month time.Time
+		m := &model.GoalProgressMonth{}
+
 		err := rows.Scan(
-			&gP.ID,
-			&gP.Amount,
-			&gP.Date,
-			&gP.Version,
-			&gP.Deleted,
-			&gP.Goal.ID,
-			&gP.Goal.Name,
-			&gP.Goal.Description,
-			&gP.Goal.User.ID,
-			&gP.Goal.Deadline,
-			&gP.Goal.Amount,
-			&gP.Goal.Current,
-			&gP.Goal.Status,
-			&gP.Goal.Version,
-			&gP.Goal.CreatedAt,
-			&gP.Goal.Deleted,
-			&gP.Goal.User.CreatedAt,
-			&gP.Goal.User.Name,
-			&gP.Goal.User.Phone,
-			&gP.Goal.User.Email,
-			&gP.Goal.User.Cod,
-			&gP.Goal.User.Activated,
-			&gP.Goal.User.Version,
-			&gP.Goal.User.ID,
+			&month,
+			&m.Contributions,
+			&m.Withdrawals,
+			&m.Net,
+			&m.Entries,
+			&m.Balance,
 		)
-
 		if err != nil {
 			return nil, err
 		}
 
-		gPs = append(gPs, gP)
+		m.Month = month.Format(model.StatementMonthLayout)
+		months = append(months, m)
 	}
 
-	return gPs, nil
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return months, nil
 }
 
 func (r *GoalProgressRepository) GetGoalProgressByID(
 	userID, gPID int64,
 ) (*model.GoalProgress, error) {
-	query := `
-	SELECT
-		gp.id,
-		gp.amount,
-		gp.date,
-		gp.version,
-		gp.created_at,
-		gp.deleted,
-		
-		g.id AS g_id, 
-		g.name AS g_name, 
-		g.description AS g_description,  
-		g.user_id AS g_user_id,
-		g.deadline AS g_deadline, 
-		g.amount AS g_amount, 
-		g.current AS g_current,
-		g.status AS g_status,
-		g.version AS g_version,
-		g.created_at AS g_created_at,
-		g.deleted AS g_deleted,
-
-		u.id AS u_id,
-		u.name AS u_name,
-		u.phone AS u_phone,
-		u.email AS u_email,
-		u.cod AS u_cod,
-		u.activated AS u_activated,
-		u.version AS u_version,
-		u.created_at AS u_created_at
-		
-	FROM
-		goal_progress gp
-	INNER JOIN goals g ON gp.goal_id = g.id
-	INNER JOIN users u ON g.user_id = u.id
+	query := goalProgressSelect + `
 	WHERE
-		g.user_id = $1
+		(
+			g.user_id = $1
+			OR (gp.user_id = $1 AND EXISTS (
+				SELECT 1 FROM goal_members m
+				WHERE m.goal_id = g.id AND m.user_id = $1 AND m.status = $3
+			))
+		)
 		AND gp.deleted = FALSE
 		AND gp.id = $2
-	ORDER BY gp.created_at DESC;
-`
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	gP := &model.GoalProgress{
-		Goal: &model.Goal{
-			User: &model.User{},
-		},
+	gP, err := scanGoalProgress(r.db.QueryRowContext(ctx, query, userID, gPID, model.GoalMemberAccepted))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
 	}
 
-	err := r.db.QueryRowContext(ctx, query, userID, gPID).Scan(
+	return gP, nil
+}
 
-		&gP.ID,
-		&gP.Amount,
-		&gP.Date,
-		&gP.Version,
-		&gP.Deleted,
-		&gP.Goal.ID,
-		&gP.Goal.Name,
-		&gP.Goal.Description,
-		&gP.Goal.User.ID,
-		&gP.Goal.Deadline,
-		&gP.Goal.Amount,
-		&gP.Goal.Current,
-		&gP.Goal.Status,
-		&gP.Goal.Version,
-		&gP.Goal.CreatedAt,
-		&gP.Goal.Deleted,
-		&gP.Goal.User.CreatedAt,
-		&gP.Goal.User.Name,
-		&gP.Goal.User.Phone,
-		&gP.Goal.User.Email,
-		&gP.Goal.User.Cod,
-		&gP.Goal.User.Activated,
-		&gP.Goal.User.Version,
-		&gP.Goal.User.ID,
-	)
+func (r *GoalProgressRepository) GetByTransactionID(
+	userID, transactionID int64,
+) (*model.GoalProgress, error) {
+	query := goalProgressSelect + `
+	WHERE
+		gp.user_id = $1
+		AND (
+			g.user_id = $1
+			OR EXISTS (
+				SELECT 1 FROM goal_members m
+				WHERE m.goal_id = g.id AND m.user_id = $1 AND m.status = $3
+			)
+		)
+		AND gp.deleted = FALSE
+		AND gp.transaction_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	gP, err := scanGoalProgress(r.db.QueryRowContext(ctx, query, userID, transactionID, model.GoalMemberAccepted))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -215,20 +332,42 @@ func (r *GoalProgressRepository) GetGoalProgressByID(
 	}
 
 	return gP, nil
+}
+
+func (r *GoalProgressRepository) GetTotal(goalID int64, tx *sql.Tx) (money.Money, error) {
+	query := `
+	SELECT COALESCE(SUM(amount), 0)
+	FROM goal_progress
+	WHERE goal_id = $1 AND deleted = FALSE
+	`
 
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var total money.Money
+	if err := tx.QueryRowContext(ctx, query, goalID).Scan(&total); err != nil {
+		return money.Zero, err
+	}
+
+	return total, nil
 }
 
-func (r *GoalProgressRepository) Insert(gP *model.GoalProgress) error {
+func (r *GoalProgressRepository) Insert(gP *model.GoalProgress, tx *sql.Tx) error {
 	query := `
 	INSERT INTO goal_progress (
+		kind,
 		amount,
+		reason,
 		date,
 		deleted,
-		goal_id
+		goal_id,
+		transaction_id,
+		transaction_created,
+		user_id
 	) VALUES (
-		$1, $2, false, $3
-	) RETURNING 
-	 	id,
+		$1, $2, $3, $4, false, $5, $6, $7, $8
+	) RETURNING
+		id,
 		created_at,
 		version
 	`
@@ -236,19 +375,36 @@ func (r *GoalProgressRepository) Insert(gP *model.GoalProgress) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
+	var transactionID *int64
+	if gP.Transaction != nil {
+		transactionID = &gP.Transaction.ID
+	}
+
 	args := []any{
+		gP.Kind,
 		gP.Amount,
+		gP.Reason,
 		gP.Date,
 		gP.Goal.ID,
+		transactionID,
+		gP.TransactionCreated,
+		gP.User.ID,
 	}
 
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+	err := tx.QueryRowContext(ctx, query, args...).Scan(
 		&gP.ID,
 		&gP.CreatedAt,
 		&gP.Version,
 	)
 
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_goal_progress_transaction":
+				return e.ErrTransactionLinked
+			}
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return e.ErrEditConflict
 		}
@@ -258,20 +414,28 @@ func (r *GoalProgressRepository) Insert(gP *model.GoalProgress) error {
 	return nil
 }
 
-func (r *GoalProgressRepository) Update(gP *model.GoalProgress, userID int64) error {
+func (r *GoalProgressRepository) Update(gP *model.GoalProgress, userID int64, tx *sql.Tx) error {
 	query := `
-	UPDATE goal_progress
+	UPDATE goal_progress gp
 	SET
 		amount = $1,
-		date = $2,
-		version = version + 1
-	inner join goals g on goal_progress.goal_id = g.id
+		reason = $2,
+		date = $3,
+		version = gp.version + 1
+	FROM goals g
 	WHERE
-		id = $3
-		and goal_id = $4
-		AND deleted = false
-		AND g.user_id = $5
-	RETURNING version
+		gp.goal_id = g.id
+		AND gp.id = $4
+		AND gp.goal_id = $5
+		AND gp.deleted = false
+		AND (
+			g.user_id = $6
+			OR (gp.user_id = $6 AND EXISTS (
+				SELECT 1 FROM goal_members m
+				WHERE m.goal_id = g.id AND m.user_id = $6 AND m.status = $7
+			))
+		)
+	RETURNING gp.version
 	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -279,13 +443,15 @@ func (r *GoalProgressRepository) Update(gP *model.GoalProgress, userID int64) er
 
 	args := []any{
 		gP.Amount,
+		gP.Reason,
 		gP.Date,
 		gP.ID,
 		gP.Goal.ID,
 		userID,
+		model.GoalMemberAccepted,
 	}
 
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&gP.Version)
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&gP.Version)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -297,22 +463,29 @@ func (r *GoalProgressRepository) Update(gP *model.GoalProgress, userID int64) er
 	return nil
 }
 
-func (r *GoalProgressRepository) Delete(goalProgressID, userID int64) error {
+func (r *GoalProgressRepository) Delete(goalProgressID, userID int64, tx *sql.Tx) error {
 	query := `
-	UPDATE goal_progress
+	UPDATE goal_progress gp
 	SET
-		deleted = true,
+		deleted = true
+	FROM goals g
 	WHERE
-		id = $1
-		AND goal_id IN (
-			SELECT id FROM goals WHERE user_id = $2
+		gp.goal_id = g.id
+		AND gp.id = $1
+		AND gp.deleted = false
+		AND (
+			g.user_id = $2
+			OR (gp.user_id = $2 AND EXISTS (
+				SELECT 1 FROM goal_members m
+				WHERE m.goal_id = g.id AND m.user_id = $2 AND m.status = $3
+			))
 		)
 	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, query, goalProgressID, userID)
+	result, err := tx.ExecContext(ctx, query, goalProgressID, userID, model.GoalMemberAccepted)
 
 	if err != nil {
 		return err
@@ -329,3 +502,67 @@ func (r *GoalProgressRepository) Delete(goalProgressID, userID int64) error {
 
 	return nil
 }
+
+type prefixedScanner struct {
+	row    interface{ Scan(dest ...any) error }
+	prefix any
+}
+
+func (s prefixedScanner) Scan(dest ...any) error {
+	return s.row.Scan(append([]any{s.prefix}, dest...)...)
+}
+
+func scanGoalProgress(row interface{ Scan(dest ...any) error }) (*model.GoalProgress, error) {
+	var transactionID sql.NullInt64
+
+	gP := &model.GoalProgress{
+		Goal: &model.Goal{
+			User: &model.User{},
+		},
+		User: &model.User{},
+	}
+
+	err := row.Scan(
+		&gP.ID,
+		&gP.Kind,
+		&gP.Amount,
+		&gP.Reason,
+		&gP.Date,
+		&gP.Version,
+		&gP.CreatedAt,
+		&gP.Deleted,
+		&transactionID,
+		&gP.TransactionCreated,
+		&gP.User.ID,
+		&gP.User.Name,
+		&gP.Goal.ID,
+		&gP.Goal.Name,
+		&gP.Goal.Description,
+		&gP.Goal.Color,
+		&gP.Goal.User.ID,
+		&gP.Goal.Deadline,
+		&gP.Goal.Amount,
+		&gP.Goal.Current,
+		&gP.Goal.Currency,
+		&gP.Goal.Status,
+		&gP.Goal.Version,
+		&gP.Goal.CreatedAt,
+		&gP.Goal.Deleted,
+		&gP.Goal.User.Name,
+		&gP.Goal.User.Phone,
+		&gP.Goal.User.Email,
+		&gP.Goal.User.Cod,
+		&gP.Goal.User.Activated,
+		&gP.Goal.User.Version,
+		&gP.Goal.User.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if transactionID.Valid {
+		gP.Transaction = &model.Transaction{ID: transactionID.Int64}
+	}
+
+	return gP, nil
+}
@@ -0,0 +1,374 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	e "financas/utils/errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type GoalScheduleRepository struct {
+	db *sql.DB
+}
+
+type GoalScheduleRepositoryInterface interface {
+	GetAll(userID int64) ([]*model.GoalSchedule, error)
+	GetByID(id, userID int64) (*model.GoalSchedule, error)
+	GetDue(date time.Time) ([]*model.GoalSchedule, error)
+	Insert(schedule *model.GoalSchedule) error
+	Update(schedule *model.GoalSchedule) error
+	Delete(id, userID int64) error
+	GetRuns(scheduleID int64) ([]*model.GoalScheduleRun, error)
+	InsertRun(run *model.GoalScheduleRun, tx *sql.Tx) error
+	UpdateNextRun(schedule *model.GoalSchedule, tx *sql.Tx) error
+}
+
+func NewGoalScheduleRepository(db *sql.DB) *GoalScheduleRepository {
+	return &GoalScheduleRepository{db: db}
+}
+
+const goalScheduleSelect = `
+	SELECT
+		s.id,
+		s.created_at,
+		s.user_id,
+		s.goal_id,
+		g.name,
+		s.mode,
+		s.amount,
+		s.day_of_month,
+		s.next_run,
+		s.paused,
+		s.version
+	FROM goal_schedules s
+	INNER JOIN goals g ON s.goal_id = g.id
+`
+
+func (r *GoalScheduleRepository) GetAll(userID int64) ([]*model.GoalSchedule, error) {
+	query := goalScheduleSelect + `
+	WHERE s.user_id = $1 AND s.deleted = false
+	ORDER BY s.next_run ASC, s.id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanGoalSchedules(rows)
+}
+
+func (r *GoalScheduleRepository) GetByID(id, userID int64) (*model.GoalSchedule, error) {
+	query := goalScheduleSelect + `
+	WHERE s.id = $1 AND s.user_id = $2 AND s.deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	schedule, err := scanGoalSchedule(r.db.QueryRowContext(ctx, query, id, userID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return schedule, nil
+}
+
+func (r *GoalScheduleRepository) GetDue(date time.Time) ([]*model.GoalSchedule, error) {
+	query := goalScheduleSelect + `
+	INNER JOIN users u ON s.user_id = u.id
+	WHERE s.next_run <= $1 AND s.deleted = false AND u.activated = true
+	ORDER BY s.next_run ASC, s.id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, date)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanGoalSchedules(rows)
+}
+
+func (r *GoalScheduleRepository) Insert(schedule *model.GoalSchedule) error {
+	query := `
+	INSERT INTO goal_schedules (user_id, goal_id, mode, amount, day_of_month, next_run)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{
+		schedule.User.ID,
+		schedule.Goal.ID,
+		schedule.Mode,
+		schedule.Amount,
+		schedule.DayOfMonth,
+		schedule.NextRun,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&schedule.ID,
+		&schedule.CreatedAt,
+		&schedule.Version,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_goal_schedule_goal":
+				return e.ErrDuplicateGoalSchedule
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *GoalScheduleRepository) Update(schedule *model.GoalSchedule) error {
+	query := `
+	UPDATE goal_schedules
+	SET
+		mode = $1,
+		amount = $2,
+		day_of_month = $3,
+		next_run = $4,
+		paused = $5,
+		version = version + 1
+	WHERE
+		id = $6
+		AND user_id = $7
+		AND deleted = false
+		AND version = $8
+	RETURNING version
+	`
+
+	args := []any{
+		schedule.Mode,
+		schedule.Amount,
+		schedule.DayOfMonth,
+		schedule.NextRun,
+		schedule.Paused,
+		schedule.ID,
+		schedule.User.ID,
+		schedule.Version,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&schedule.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return e.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *GoalScheduleRepository) Delete(id, userID int64) error {
+	query := `
+	UPDATE goal_schedules
+	SET deleted = true
+	WHERE id = $1 AND user_id = $2 AND deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *GoalScheduleRepository) GetRuns(scheduleID int64) ([]*model.GoalScheduleRun, error) {
+	query := `
+	SELECT id, schedule_id, due_date, status, amount, goal_progress_id, reason, created_at
+	FROM goal_schedule_runs
+	WHERE schedule_id = $1
+	ORDER BY due_date DESC, id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	runs := []*model.GoalScheduleRun{}
+	for rows.Next() {
+		var goalProgressID sql.NullInt64
+		run := &model.GoalScheduleRun{}
+
+		err := rows.Scan(
+			&run.ID,
+			&run.ScheduleID,
+			&run.DueDate,
+			&run.Status,
+			&run.Amount,
+			&goalProgressID,
+			&run.Reason,
+			&run.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if goalProgressID.Valid {
+			run.GoalProgressID = &goalProgressID.Int64
+		}
+
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (r *GoalScheduleRepository) InsertRun(run *model.GoalScheduleRun, tx *sql.Tx) error {
+	query := `
+	INSERT INTO goal_schedule_runs (schedule_id, due_date, status, amount, goal_progress_id, reason)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT ON CONSTRAINT unique_goal_schedule_run DO NOTHING
+	RETURNING id, created_at
+	`
+
+	args := []any{
+		run.ScheduleID,
+		run.DueDate,
+		run.Status,
+		run.Amount,
+		run.GoalProgressID,
+		run.Reason,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&run.ID, &run.CreatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return e.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *GoalScheduleRepository) UpdateNextRun(schedule *model.GoalSchedule, tx *sql.Tx) error {
+	query := `
+	UPDATE goal_schedules
+	SET next_run = $1, version = version + 1
+	WHERE id = $2 AND deleted = false AND version = $3
+	RETURNING version
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := tx.QueryRowContext(ctx, query, schedule.NextRun, schedule.ID, schedule.Version).Scan(&schedule.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return e.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+type goalScheduleScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanGoalSchedule(row goalScheduleScanner) (*model.GoalSchedule, error) {
+	schedule := &model.GoalSchedule{
+		User: &model.User{},
+		Goal: &model.Goal{},
+	}
+
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.CreatedAt,
+		&schedule.User.ID,
+		&schedule.Goal.ID,
+		&schedule.Goal.Name,
+		&schedule.Mode,
+		&schedule.Amount,
+		&schedule.DayOfMonth,
+		&schedule.NextRun,
+		&schedule.Paused,
+		&schedule.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func scanGoalSchedules(rows *sql.Rows) ([]*model.GoalSchedule, error) {
+	schedules := []*model.GoalSchedule{}
+	for rows.Next() {
+		schedule, err := scanGoalSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
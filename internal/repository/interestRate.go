@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"financas/internal/model"
+	e "financas/utils/errors"
+	"time"
+)
+
+type InterestRateRepository struct {
+	db *sql.DB
+}
+
+type InterestRateRepositoryInterface interface {
+	GetAll(userID int64, limit int) ([]*model.InterestRate, error)
+	Upsert(rate *model.InterestRate) error
+	Delete(id, userID int64) error
+}
+
+func NewInterestRateRepository(db *sql.DB) *InterestRateRepository {
+	return &InterestRateRepository{db: db}
+}
+
+func (r *InterestRateRepository) GetAll(userID int64, limit int) ([]*model.InterestRate, error) {
+	query := `
+	SELECT id, month, annual_rate, created_at, version
+	FROM interest_rates
+	WHERE user_id = $1
+	ORDER BY month DESC
+	LIMIT NULLIF($2, 0)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rates := []*model.InterestRate{}
+	for rows.Next() {
+		rate := &model.InterestRate{User: &model.User{ID: userID}}
+
+		err := rows.Scan(
+			&rate.ID,
+			&rate.Month,
+			&rate.AnnualRate,
+			&rate.CreatedAt,
+			&rate.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *InterestRateRepository) Upsert(rate *model.InterestRate) error {
+	query := `
+	INSERT INTO interest_rates (user_id, month, annual_rate)
+	VALUES ($1, $2, $3)
+	ON CONFLICT ON CONSTRAINT unique_user_interest_rate_month
+	DO UPDATE SET
+		annual_rate = EXCLUDED.annual_rate,
+		version = interest_rates.version + 1
+	RETURNING id, created_at, version
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, rate.User.ID, rate.Month, rate.AnnualRate).Scan(
+		&rate.ID,
+		&rate.CreatedAt,
+		&rate.Version,
+	)
+}
+
+func (r *InterestRateRepository) Delete(id, userID int64) error {
+	query := `
+	DELETE FROM interest_rates
+	WHERE id = $1 AND user_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
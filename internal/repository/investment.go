@@ -0,0 +1,555 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	e "financas/utils/errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type InvestmentRepository struct {
+	db *sql.DB
+}
+
+type InvestmentRepositoryInterface interface {
+	GetAll(name string, userID int64, f filters.Filters) ([]*model.Investment, filters.Metadata, error)
+	GetAllWithHistory(userID int64) ([]*model.Investment, error)
+	GetByID(id, userID int64) (*model.Investment, error)
+	Insert(investment *model.Investment) error
+	Update(investment *model.Investment) error
+	Delete(id, userID int64) error
+	GetOperations(investmentID int64) ([]*model.InvestmentOperation, error)
+	InsertOperation(operation *model.InvestmentOperation) error
+	DeleteOperation(id, investmentID int64) error
+	GetPrices(investmentID int64) ([]*model.InvestmentPrice, error)
+	UpsertPrice(price *model.InvestmentPrice, tx *sql.Tx) error
+	DeletePrice(id, investmentID int64) error
+}
+
+func NewInvestmentRepository(db *sql.DB) *InvestmentRepository {
+	return &InvestmentRepository{db: db}
+}
+
+func (r *InvestmentRepository) GetAll(name string, userID int64, f filters.Filters) ([]*model.Investment, filters.Metadata, error) {
+	query := fmt.Sprintf(`
+	SELECT
+		count(*) OVER(),
+		id,
+		created_at,
+		ticker,
+		name,
+		kind,
+		currency,
+		version
+	FROM investments
+	WHERE
+		(to_tsvector('simple', name || ' ' || COALESCE(ticker, '')) @@ plainto_tsquery('simple', $1) OR $1 = '')
+		AND user_id = $2
+		AND deleted = false
+	ORDER BY %s %s, id ASC
+	LIMIT $3 OFFSET $4
+	`, f.SortColumn(), f.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, name, userID, f.Limit(), f.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	investments := []*model.Investment{}
+
+	for rows.Next() {
+		var ticker sql.NullString
+		investment := &model.Investment{User: &model.User{ID: userID}}
+
+		err := rows.Scan(
+			&totalRecords,
+			&investment.ID,
+			&investment.CreatedAt,
+			&ticker,
+			&investment.Name,
+			&investment.Kind,
+			&investment.Currency,
+			&investment.Version,
+		)
+		if err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		investment.Ticker = ticker.String
+		investments = append(investments, investment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metaData := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return investments, metaData, nil
+}
+
+func (r *InvestmentRepository) GetAllWithHistory(userID int64) ([]*model.Investment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+	SELECT id, created_at, ticker, name, kind, currency, version
+	FROM investments
+	WHERE user_id = $1 AND deleted = false
+	ORDER BY id ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	investments := []*model.Investment{}
+	byID := map[int64]*model.Investment{}
+
+	for rows.Next() {
+		var ticker sql.NullString
+		investment := &model.Investment{User: &model.User{ID: userID}}
+
+		err := rows.Scan(
+			&investment.ID,
+			&investment.CreatedAt,
+			&ticker,
+			&investment.Name,
+			&investment.Kind,
+			&investment.Currency,
+			&investment.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		investment.Ticker = ticker.String
+		investments = append(investments, investment)
+		byID[investment.ID] = investment
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	operations, err := r.db.QueryContext(ctx, `
+	SELECT o.id, o.investment_id, o.type, o.date, o.quantity, o.price, o.fees, o.amount, o.created_at
+	FROM investment_operations o
+	INNER JOIN investments i ON (i.id = o.investment_id)
+	WHERE i.user_id = $1 AND i.deleted = false
+	ORDER BY o.date ASC, o.id ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer operations.Close()
+
+	for operations.Next() {
+		operation := &model.InvestmentOperation{}
+
+		err := operations.Scan(
+			&operation.ID,
+			&operation.InvestmentID,
+			&operation.Type,
+			&operation.Date,
+			&operation.Quantity,
+			&operation.Price,
+			&operation.Fees,
+			&operation.Amount,
+			&operation.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if investment, ok := byID[operation.InvestmentID]; ok {
+			investment.Operations = append(investment.Operations, operation)
+		}
+	}
+
+	if err = operations.Err(); err != nil {
+		return nil, err
+	}
+
+	prices, err := r.db.QueryContext(ctx, `
+	SELECT p.id, p.investment_id, p.date, p.price, p.created_at
+	FROM investment_prices p
+	INNER JOIN investments i ON (i.id = p.investment_id)
+	WHERE i.user_id = $1 AND i.deleted = false
+	ORDER BY p.date ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer prices.Close()
+
+	for prices.Next() {
+		price := &model.InvestmentPrice{}
+
+		err := prices.Scan(
+			&price.ID,
+			&price.InvestmentID,
+			&price.Date,
+			&price.Price,
+			&price.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if investment, ok := byID[price.InvestmentID]; ok {
+			investment.Prices = append(investment.Prices, price)
+		}
+	}
+
+	if err = prices.Err(); err != nil {
+		return nil, err
+	}
+
+	return investments, nil
+}
+
+func (r *InvestmentRepository) GetByID(id, userID int64) (*model.Investment, error) {
+	query := `
+	SELECT id, created_at, ticker, name, kind, currency, version
+	FROM investments
+	WHERE id = $1 AND user_id = $2 AND deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var ticker sql.NullString
+	investment := &model.Investment{User: &model.User{ID: userID}}
+
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
+		&investment.ID,
+		&investment.CreatedAt,
+		&ticker,
+		&investment.Name,
+		&investment.Kind,
+		&investment.Currency,
+		&investment.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	investment.Ticker = ticker.String
+	return investment, nil
+}
+
+func (r *InvestmentRepository) Insert(investment *model.Investment) error {
+	query := `
+	INSERT INTO investments (user_id, ticker, name, kind, currency)
+	VALUES ($1, NULLIF($2, ''), $3, $4, $5)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{
+		investment.User.ID,
+		investment.Ticker,
+		investment.Name,
+		investment.Kind,
+		investment.Currency,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&investment.ID, &investment.CreatedAt, &investment.Version)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_user_investment_ticker":
+				return e.ErrDuplicateTicker
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *InvestmentRepository) Update(investment *model.Investment) error {
+	query := `
+	UPDATE investments
+	SET
+		ticker = NULLIF($1, ''),
+		name = $2,
+		kind = $3,
+		currency = $4,
+		version = version + 1
+	WHERE
+		id = $5
+		AND user_id = $6
+		AND deleted = false
+		AND version = $7
+	RETURNING created_at, version
+	`
+
+	args := []any{
+		investment.Ticker,
+		investment.Name,
+		investment.Kind,
+		investment.Currency,
+		investment.ID,
+		investment.User.ID,
+		investment.Version,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&investment.CreatedAt, &investment.Version)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_user_investment_ticker":
+				return e.ErrDuplicateTicker
+			}
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrEditConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *InvestmentRepository) Delete(id, userID int64) error {
+	query := `
+	UPDATE investments
+	SET
+		deleted = true
+	WHERE
+		id = $1
+		AND user_id = $2
+		AND deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *InvestmentRepository) GetOperations(investmentID int64) ([]*model.InvestmentOperation, error) {
+	query := `
+	SELECT id, type, date, quantity, price, fees, amount, created_at
+	FROM investment_operations
+	WHERE investment_id = $1
+	ORDER BY date ASC, id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, investmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	operations := []*model.InvestmentOperation{}
+
+	for rows.Next() {
+		operation := &model.InvestmentOperation{InvestmentID: investmentID}
+
+		err := rows.Scan(
+			&operation.ID,
+			&operation.Type,
+			&operation.Date,
+			&operation.Quantity,
+			&operation.Price,
+			&operation.Fees,
+			&operation.Amount,
+			&operation.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, operation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return operations, nil
+}
+
+func (r *InvestmentRepository) InsertOperation(operation *model.InvestmentOperation) error {
+	query := `
+	INSERT INTO investment_operations (investment_id, type, date, quantity, price, fees, amount)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at
+	`
+
+	args := []any{
+		operation.InvestmentID,
+		operation.Type,
+		operation.Date,
+		operation.Quantity,
+		operation.Price,
+		operation.Fees,
+		operation.Amount,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, args...).Scan(&operation.ID, &operation.CreatedAt)
+}
+
+func (r *InvestmentRepository) DeleteOperation(id, investmentID int64) error {
+	query := `
+	DELETE FROM investment_operations
+	WHERE id = $1 AND investment_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, investmentID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *InvestmentRepository) GetPrices(investmentID int64) ([]*model.InvestmentPrice, error) {
+	query := `
+	SELECT id, date, price, created_at
+	FROM investment_prices
+	WHERE investment_id = $1
+	ORDER BY date ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, investmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	prices := []*model.InvestmentPrice{}
+
+	for rows.Next() {
+		price := &model.InvestmentPrice{InvestmentID: investmentID}
+
+		err := rows.Scan(
+			&price.ID,
+			&price.Date,
+			&price.Price,
+			&price.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		prices = append(prices, price)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func (r *InvestmentRepository) UpsertPrice(price *model.InvestmentPrice, tx *sql.Tx) error {
+	query := `
+	INSERT INTO investment_prices (investment_id, date, price)
+	VALUES ($1, $2, $3)
+	ON CONFLICT ON CONSTRAINT unique_investment_price_date
+	DO UPDATE SET
+		price = EXCLUDED.price
+	RETURNING id, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return tx.QueryRowContext(ctx, query, price.InvestmentID, price.Date, price.Price).Scan(
+		&price.ID,
+		&price.CreatedAt,
+	)
+}
+
+func (r *InvestmentRepository) DeletePrice(id, investmentID int64) error {
+	query := `
+	DELETE FROM investment_prices
+	WHERE id = $1 AND investment_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, investmentID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
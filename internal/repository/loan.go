@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	e "financas/utils/errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type LoanRepository struct {
+	db *sql.DB
+}
+
+type LoanRepositoryInterface interface {
+	GetAll(name string, userID int64, f filters.Filters) ([]*model.Loan, filters.Metadata, error)
+	GetByID(id, userID int64) (*model.Loan, error)
+	Insert(loan *model.Loan) error
+	Update(loan *model.Loan) error
+	Delete(id, userID int64) error
+	GetPrepayments(loanID int64) ([]*model.LoanPrepayment, error)
+	InsertPrepayment(prepayment *model.LoanPrepayment) error
+	DeletePrepayment(id, loanID int64) error
+	GetPayments(loanID int64) ([]*model.LoanPayment, error)
+	InsertPayment(payment *model.LoanPayment) error
+	DeletePayment(loanID int64, installment int) error
+}
+
+func NewLoanRepository(db *sql.DB) *LoanRepository {
+	return &LoanRepository{db: db}
+}
+
+func (r *LoanRepository) GetAll(name string, userID int64, f filters.Filters) ([]*model.Loan, filters.Metadata, error) {
+	query := fmt.Sprintf(`
+	SELECT
+		count(*) OVER(),
+		id,
+		created_at,
+		name,
+		system,
+		principal,
+		annual_rate,
+		term,
+		start_date,
+		currency,
+		version
+	FROM loans
+	WHERE
+		(to_tsvector('simple', name) @@ plainto_tsquery('simple', $1) OR $1 = '')
+		AND user_id = $2
+		AND deleted = false
+	ORDER BY %s %s, id ASC
+	LIMIT $3 OFFSET $4
+	`, f.SortColumn(), f.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, name, userID, f.Limit(), f.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	loans := []*model.Loan{}
+
+	for rows.Next() {
+		loan := &model.Loan{User: &model.User{ID: userID}}
+
+		err := rows.Scan(
+			&totalRecords,
+			&loan.ID,
+			&loan.CreatedAt,
+			&loan.Name,
+			&loan.System,
+			&loan.Principal,
+			&loan.AnnualRate,
+			&loan.Term,
+			&loan.StartDate,
+			&loan.Currency,
+			&loan.Version,
+		)
+		if err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		loans = append(loans, loan)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metaData := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return loans, metaData, nil
+}
+
+func (r *LoanRepository) GetByID(id, userID int64) (*model.Loan, error) {
+	query := `
+	SELECT id, created_at, name, system, principal, annual_rate, term, start_date, currency, version
+	FROM loans
+	WHERE id = $1 AND user_id = $2 AND deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	loan := &model.Loan{User: &model.User{ID: userID}}
+
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
+		&loan.ID,
+		&loan.CreatedAt,
+		&loan.Name,
+		&loan.System,
+		&loan.Principal,
+		&loan.AnnualRate,
+		&loan.Term,
+		&loan.StartDate,
+		&loan.Currency,
+		&loan.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return loan, nil
+}
+
+func (r *LoanRepository) Insert(loan *model.Loan) error {
+	query := `
+	INSERT INTO loans (user_id, name, system, principal, annual_rate, term, start_date, currency)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{
+		loan.User.ID,
+		loan.Name,
+		loan.System,
+		loan.Principal,
+		loan.AnnualRate,
+		loan.Term,
+		loan.StartDate,
+		loan.Currency,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, args...).Scan(&loan.ID, &loan.CreatedAt, &loan.Version)
+}
+
+func (r *LoanRepository) Update(loan *model.Loan) error {
+	query := `
+	UPDATE loans
+	SET
+		name = $1,
+		system = $2,
+		principal = $3,
+		annual_rate = $4,
+		term = $5,
+		start_date = $6,
+		currency = $7,
+		version = version + 1
+	WHERE
+		id = $8
+		AND user_id = $9
+		AND deleted = false
+		AND version = $10
+	RETURNING created_at, version
+	`
+
+	args := []any{
+		loan.Name,
+		loan.System,
+		loan.Principal,
+		loan.AnnualRate,
+		loan.Term,
+		loan.StartDate,
+		loan.Currency,
+		loan.ID,
+		loan.User.ID,
+		loan.Version,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&loan.CreatedAt, &loan.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrEditConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *LoanRepository) Delete(id, userID int64) error {
+	query := `
+	UPDATE loans
+	SET
+		deleted = true
+	WHERE
+		id = $1
+		AND user_id = $2
+		AND deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *LoanRepository) GetPrepayments(loanID int64) ([]*model.LoanPrepayment, error) {
+	query := `
+	SELECT id, amount, date, mode, created_at
+	FROM loan_prepayments
+	WHERE loan_id = $1
+	ORDER BY date ASC, id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	prepayments := []*model.LoanPrepayment{}
+
+	for rows.Next() {
+		prepayment := &model.LoanPrepayment{LoanID: loanID}
+
+		err := rows.Scan(
+			&prepayment.ID,
+			&prepayment.Amount,
+			&prepayment.Date,
+			&prepayment.Mode,
+			&prepayment.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		prepayments = append(prepayments, prepayment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prepayments, nil
+}
+
+func (r *LoanRepository) InsertPrepayment(prepayment *model.LoanPrepayment) error {
+	query := `
+	INSERT INTO loan_prepayments (loan_id, amount, date, mode)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, prepayment.LoanID, prepayment.Amount, prepayment.Date, prepayment.Mode).Scan(
+		&prepayment.ID,
+		&prepayment.CreatedAt,
+	)
+}
+
+func (r *LoanRepository) DeletePrepayment(id, loanID int64) error {
+	query := `
+	DELETE FROM loan_prepayments
+	WHERE id = $1 AND loan_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, loanID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *LoanRepository) GetPayments(loanID int64) ([]*model.LoanPayment, error) {
+	query := `
+	SELECT p.id, p.installment, p.transaction_id, p.created_at
+	FROM loan_payments p
+	INNER JOIN transactions t ON (t.id = p.transaction_id)
+	WHERE p.loan_id = $1 AND t.deleted = false
+	ORDER BY p.installment ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	payments := []*model.LoanPayment{}
+
+	for rows.Next() {
+		payment := &model.LoanPayment{LoanID: loanID}
+
+		err := rows.Scan(
+			&payment.ID,
+			&payment.Installment,
+			&payment.TransactionID,
+			&payment.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+func (r *LoanRepository) InsertPayment(payment *model.LoanPayment) error {
+	query := `
+	INSERT INTO loan_payments (loan_id, installment, transaction_id)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, payment.LoanID, payment.Installment, payment.TransactionID).Scan(
+		&payment.ID,
+		&payment.CreatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "unique_loan_payment_transaction":
+				return e.ErrDuplicateLoanPayment
+			case "unique_loan_installment":
+				return e.ErrInstallmentPaid
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *LoanRepository) DeletePayment(loanID int64, installment int) error {
+	query := `
+	DELETE FROM loan_payments
+	WHERE loan_id = $1 AND installment = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, loanID, installment)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"financas/internal/model"
+	"time"
+)
+
+type PermissionRepository struct {
+	db *sql.DB
+}
+
+type PermissionRepositoryInterface interface {
+	GetAllForUser(userID int64) (model.Permissions, error)
+}
+
+func NewPermissionRepository(db *sql.DB) *PermissionRepository {
+	return &PermissionRepository{db: db}
+}
+
+func (r *PermissionRepository) GetAllForUser(userID int64) (model.Permissions, error) {
+	query := `
+	SELECT p.code
+	FROM permissions p
+	INNER JOIN roles_permissions rp ON (rp.permission_id = p.id)
+	INNER JOIN users u ON (u.role = rp.role)
+	WHERE 
+		u.id = $1
+		AND u.deleted = false
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var permissions model.Permissions
+
+	for rows.Next() {
+		var permission string
+
+		err := rows.Scan(&permission)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
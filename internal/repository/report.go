@@ -0,0 +1,527 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"financas/internal/model"
+	"financas/internal/model/money"
+	e "financas/utils/errors"
+	"fmt"
+	"time"
+)
+
+type ReportRepository struct {
+	db *sql.DB
+}
+
+type ReportRepositoryInterface interface {
+	GetAggregates(userID int64, start, end time.Time, trend *model.TrendRange) (*model.ReportAggregates, error)
+	GetTrend(userID int64, trend *model.TrendRange) (*model.ReportAggregates, error)
+	GetBalance(userID int64, until time.Time) (money.Money, string, error)
+	GetConvertedTransactions(userID int64, start, end time.Time) ([]*model.Transaction, string, error)
+}
+
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+const sqlConvertedTransactions = `
+	WITH base AS (
+		SELECT base_currency
+		FROM users
+		WHERE id = $1
+	),
+	converted AS (
+		SELECT
+			t.id,
+			t.description,
+			t.category_id,
+			t.created_at,
+			t.currency,
+			CASE
+				WHEN t.currency = b.base_currency THEN t.amount
+				WHEN d.rate IS NOT NULL THEN ROUND(t.amount * d.rate, 2)
+				WHEN i.rate IS NOT NULL THEN ROUND(t.amount / i.rate, 2)
+			END AS amount
+		FROM transactions t
+		CROSS JOIN base b
+		LEFT JOIN LATERAL (
+			SELECT er.rate
+			FROM exchange_rates er
+			WHERE 
+				er.user_id = t.user_id
+				AND er.from_currency = t.currency
+				AND er.to_currency = b.base_currency
+				AND er.date <= (t.created_at AT TIME ZONE 'UTC')::date
+			ORDER BY er.date DESC
+			LIMIT 1
+		) d ON true
+		LEFT JOIN LATERAL (
+			SELECT er.rate
+			FROM exchange_rates er
+			WHERE 
+				er.user_id = t.user_id
+				AND er.from_currency = b.base_currency
+				AND er.to_currency = t.currency
+				AND er.date <= (t.created_at AT TIME ZONE 'UTC')::date
+			ORDER BY er.date DESC
+			LIMIT 1
+		) i ON true
+		WHERE 
+			t.user_id = $1
+			AND t.deleted = false
+			AND (%s)
+	)
+`
+
+func (r *ReportRepository) GetAggregates(
+	userID int64,
+	start, end time.Time,
+	trend *model.TrendRange,
+) (*model.ReportAggregates, error) {
+	query := fmt.Sprintf(sqlConvertedTransactions, `
+		(t.created_at >= $2 AND t.created_at < $3)
+		OR ($4::text IS NOT NULL AND t.created_at >= $5 AND t.created_at < $6 AND ($7 = 0 OR t.category_id = $7))
+	`) + `
+	SELECT
+		'category',
+		c.id,
+		c.created_at,
+		c.name::text,
+		c.type,
+		c.color,
+		c.version,
+		NULL::timestamptz,
+		COALESCE(SUM(cv.amount), 0),
+		COUNT(*),
+		COUNT(*) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.currency) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.created_at) FILTER (WHERE cv.amount IS NULL)
+	FROM converted cv
+	INNER JOIN categories c ON (cv.category_id = c.id)
+	WHERE cv.created_at >= $2 AND cv.created_at < $3
+	GROUP BY c.id
+
+	UNION ALL
+
+	SELECT
+		'period',
+		NULL,
+		NULL,
+		NULL,
+		c.type,
+		NULL,
+		NULL,
+		date_trunc($4::text, cv.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC',
+		COALESCE(SUM(cv.amount), 0),
+		COUNT(*),
+		COUNT(*) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.currency) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.created_at) FILTER (WHERE cv.amount IS NULL)
+	FROM converted cv
+	INNER JOIN categories c ON (cv.category_id = c.id)
+	WHERE 
+		$4::text IS NOT NULL
+		AND cv.created_at >= $5 
+		AND cv.created_at < $6
+		AND ($7 = 0 OR cv.category_id = $7)
+	GROUP BY c.type, 8
+
+	UNION ALL
+
+	SELECT
+		'base',
+		NULL,
+		NULL,
+		base_currency::text,
+		NULL,
+		NULL,
+		NULL,
+		NULL,
+		0,
+		0,
+		0,
+		NULL,
+		NULL
+	FROM base
+	`
+
+	granularity := sql.NullString{}
+	trendStart := sql.NullTime{}
+	trendEnd := sql.NullTime{}
+	var categoryID int64
+
+	if trend != nil {
+		granularity = sql.NullString{String: trend.Granularity, Valid: true}
+		trendStart = sql.NullTime{Time: trend.Start, Valid: true}
+		trendEnd = sql.NullTime{Time: trend.End, Valid: true}
+		categoryID = trend.CategoryID
+	}
+
+	args := []any{
+		userID,
+		start,
+		end,
+		granularity,
+		trendStart,
+		trendEnd,
+		categoryID,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	aggregates := &model.ReportAggregates{
+		Currency:   model.DefaultCurrency,
+		Categories: []*model.CategoryTotal{},
+		Periods:    []*model.PeriodTotal{},
+	}
+	periods := make(map[time.Time]*model.PeriodTotal)
+
+	var missingCurrency string
+	var missingDate time.Time
+
+	for rows.Next() {
+		var (
+			kind              string
+			categoryID        sql.NullInt64
+			categoryCreatedAt sql.NullTime
+			name              sql.NullString
+			categoryType      sql.NullInt64
+			color             sql.NullString
+			version           sql.NullInt64
+			period            sql.NullTime
+			total             money.Money
+			count             int
+			missing           int
+			firstMissing      sql.NullString
+			firstMissingDate  sql.NullTime
+		)
+
+		err := rows.Scan(
+			&kind,
+			&categoryID,
+			&categoryCreatedAt,
+			&name,
+			&categoryType,
+			&color,
+			&version,
+			&period,
+			&total,
+			&count,
+			&missing,
+			&firstMissing,
+			&firstMissingDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if missing > 0 && missingCurrency == "" {
+			missingCurrency = firstMissing.String
+			missingDate = firstMissingDate.Time
+		}
+
+		switch kind {
+		case "base":
+			aggregates.Currency = name.String
+		case "category":
+			aggregates.Categories = append(aggregates.Categories, &model.CategoryTotal{
+				Category: &model.Category{
+					ID:        categoryID.Int64,
+					CreatedAt: categoryCreatedAt.Time,
+					Name:      name.String,
+					Type:      model.TypeCategoria(categoryType.Int64),
+					Color:     color.String,
+					User:      &model.User{ID: userID},
+					Version:   int(version.Int64),
+				},
+				Total: total,
+				Count: count,
+			})
+		case "period":
+			p, exist := periods[period.Time]
+			if !exist {
+				p = &model.PeriodTotal{Start: period.Time}
+				periods[period.Time] = p
+				aggregates.Periods = append(aggregates.Periods, p)
+			}
+
+			if model.TypeCategoria(categoryType.Int64) == model.RECEITA {
+				p.Income = p.Income.Add(total)
+			} else {
+				p.Expenses = p.Expenses.Add(total)
+			}
+			p.Count += count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if missingCurrency != "" {
+		return nil, missingRateError(missingCurrency, aggregates.Currency, missingDate)
+	}
+
+	return aggregates, nil
+}
+
+func (r *ReportRepository) GetTrend(userID int64, trend *model.TrendRange) (*model.ReportAggregates, error) {
+	query := fmt.Sprintf(sqlConvertedTransactions, `
+		t.created_at >= $2 AND t.created_at < $3 AND ($4 = 0 OR t.category_id = $4)
+	`) + `
+	SELECT
+		c.type,
+		date_trunc($5::text, cv.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC',
+		COALESCE(SUM(cv.amount), 0),
+		COUNT(*),
+		COUNT(*) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.currency) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.created_at) FILTER (WHERE cv.amount IS NULL),
+		NULL
+	FROM converted cv
+	INNER JOIN categories c ON (cv.category_id = c.id)
+	GROUP BY c.type, 2
+
+	UNION ALL
+
+	SELECT
+		NULL,
+		NULL,
+		0,
+		0,
+		0,
+		NULL,
+		NULL,
+		base_currency::text
+	FROM base
+	`
+
+	args := []any{
+		userID,
+		trend.Start,
+		trend.End,
+		trend.CategoryID,
+		trend.Granularity,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	aggregates := &model.ReportAggregates{
+		Currency:   model.DefaultCurrency,
+		Categories: []*model.CategoryTotal{},
+		Periods:    []*model.PeriodTotal{},
+	}
+	periods := make(map[time.Time]*model.PeriodTotal)
+
+	var missingCurrency string
+	var missingDate time.Time
+
+	for rows.Next() {
+		var (
+			categoryType     sql.NullInt64
+			period           sql.NullTime
+			total            money.Money
+			count            int
+			missing          int
+			firstMissing     sql.NullString
+			firstMissingDate sql.NullTime
+			baseCurrency     sql.NullString
+		)
+
+		err := rows.Scan(
+			&categoryType,
+			&period,
+			&total,
+			&count,
+			&missing,
+			&firstMissing,
+			&firstMissingDate,
+			&baseCurrency,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if baseCurrency.Valid {
+			aggregates.Currency = baseCurrency.String
+			continue
+		}
+
+		if missing > 0 && missingCurrency == "" {
+			missingCurrency = firstMissing.String
+			missingDate = firstMissingDate.Time
+		}
+
+		p, exist := periods[period.Time]
+		if !exist {
+			p = &model.PeriodTotal{Start: period.Time}
+			periods[period.Time] = p
+			aggregates.Periods = append(aggregates.Periods, p)
+		}
+
+		if model.TypeCategoria(categoryType.Int64) == model.RECEITA {
+			p.Income = p.Income.Add(total)
+		} else {
+			p.Expenses = p.Expenses.Add(total)
+		}
+		p.Count += count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if missingCurrency != "" {
+		return nil, missingRateError(missingCurrency, aggregates.Currency, missingDate)
+	}
+
+	return aggregates, nil
+}
+
+func (r *ReportRepository) GetBalance(userID int64, until time.Time) (money.Money, string, error) {
+	query := fmt.Sprintf(sqlConvertedTransactions, "t.created_at < $2") + `
+	SELECT
+		COALESCE(SUM(CASE WHEN c.type = 1 THEN cv.amount ELSE -cv.amount END), 0),
+		COUNT(cv.id) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.currency) FILTER (WHERE cv.amount IS NULL),
+		MIN(cv.created_at) FILTER (WHERE cv.amount IS NULL),
+		(SELECT base_currency::text FROM base)
+	FROM converted cv
+	INNER JOIN categories c ON (cv.category_id = c.id)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var (
+		balance         money.Money
+		missing         int
+		missingCurrency sql.NullString
+		missingDate     sql.NullTime
+		baseCurrency    sql.NullString
+	)
+
+	err := r.db.QueryRowContext(ctx, query, userID, until).Scan(
+		&balance,
+		&missing,
+		&missingCurrency,
+		&missingDate,
+		&baseCurrency,
+	)
+	if err != nil {
+		return 0, "", err
+	}
+
+	currency := model.DefaultCurrency
+	if baseCurrency.Valid {
+		currency = baseCurrency.String
+	}
+
+	if missing > 0 {
+		return 0, "", missingRateError(missingCurrency.String, currency, missingDate.Time)
+	}
+
+	return balance, currency, nil
+}
+
+func (r *ReportRepository) GetConvertedTransactions(userID int64, start, end time.Time) ([]*model.Transaction, string, error) {
+	query := fmt.Sprintf(sqlConvertedTransactions, "t.created_at >= $2 AND t.created_at < $3") + `
+	SELECT
+		cv.id,
+		cv.created_at,
+		cv.description,
+		cv.currency,
+		COALESCE(cv.amount, 0),
+		cv.amount IS NULL,
+		c.id,
+		c.created_at,
+		c.name,
+		c.type,
+		c.color,
+		c.version,
+		(SELECT base_currency::text FROM base)
+	FROM converted cv
+	INNER JOIN categories c ON (cv.category_id = c.id)
+	ORDER BY cv.created_at ASC, cv.id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, start, end)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer rows.Close()
+
+	currency := model.DefaultCurrency
+	transactions := []*model.Transaction{}
+
+	for rows.Next() {
+		var missing bool
+		transaction := &model.Transaction{
+			User:     &model.User{ID: userID},
+			Category: &model.Category{User: &model.User{ID: userID}},
+		}
+
+		err := rows.Scan(
+			&transaction.ID,
+			&transaction.CreatedAt,
+			&transaction.Description,
+			&transaction.Currency,
+			&transaction.Amount,
+			&missing,
+			&transaction.Category.ID,
+			&transaction.Category.CreatedAt,
+			&transaction.Category.Name,
+			&transaction.Category.Type,
+			&transaction.Category.Color,
+			&transaction.Category.Version,
+			&currency,
+		)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if missing {
+			return nil, "", missingRateError(transaction.Currency, currency, transaction.CreatedAt)
+		}
+
+		transaction.Currency = currency
+		transactions = append(transactions, transaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return transactions, currency, nil
+}
+
+func missingRateError(from, to string, date time.Time) error {
+	return fmt.Errorf(
+		"%w: no %s to %s exchange rate effective on %s",
+		e.ErrExchangeRateNotFound,
+		from,
+		to,
+		date.Format(model.ExchangeRateDateLayout),
+	)
+}
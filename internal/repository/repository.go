@@ -5,19 +5,45 @@ import (
 )
 
 type Repository struct {
-	User         UserRepository
-	Category     CategoryRepositoryIntercafe
-	Transaction  TransactionRepositoryInterface
-	Goal         GoalRepositoryInterface
-	GoalProgress GoalProgressRepositoryInterface
+	User          UserRepository
+	Category      CategoryRepositoryIntercafe
+	Transaction   TransactionRepositoryInterface
+	Goal          GoalRepositoryInterface
+	GoalProgress  GoalProgressRepositoryInterface
+	Permission    PermissionRepositoryInterface
+	Admin         AdminRepositoryInterface
+	ExchangeRate  ExchangeRateRepositoryInterface
+	Report        ReportRepositoryInterface
+	Statement     StatementRepositoryInterface
+	Subscription  SubscriptionRepositoryInterface
+	Asset         AssetRepositoryInterface
+	Loan          LoanRepositoryInterface
+	Investment    InvestmentRepositoryInterface
+	GoalSchedule  GoalScheduleRepositoryInterface
+	GoalMilestone GoalMilestoneRepositoryInterface
+	InterestRate  InterestRateRepositoryInterface
+	GoalMember    GoalMemberRepositoryInterface
 }
 
 func NewRepository(db *sql.DB) *Repository {
 	return &Repository{
-		User:         NewUserRepository(db),
-		Category:     NewCategoryRepository(db),
-		Transaction:  NewTransactionRepository(db),
-		Goal:         NewGoalRepository(db),
-		GoalProgress: NewGoalProgressRepository(db),
+		User:          NewUserRepository(db),
+		Category:      NewCategoryRepository(db),
+		Transaction:   NewTransactionRepository(db),
+		Goal:          NewGoalRepository(db),
+		GoalProgress:  NewGoalProgressRepository(db),
+		Permission:    NewPermissionRepository(db),
+		Admin:         NewAdminRepository(db),
+		ExchangeRate:  NewExchangeRateRepository(db),
+		Report:        NewReportRepository(db),
+		Statement:     NewStatementRepository(db),
+		Subscription:  NewSubscriptionRepository(db),
+		Asset:         NewAssetRepository(db),
+		Loan:          NewLoanRepository(db),
+		Investment:    NewInvestmentRepository(db),
+		GoalSchedule:  NewGoalScheduleRepository(db),
+		GoalMilestone: NewGoalMilestoneRepository(db),
+		InterestRate:  NewInterestRateRepository(db),
+		GoalMember:    NewGoalMemberRepository(db),
 	}
 }
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	e "financas/utils/errors"
+	"time"
+)
+
+type StatementRepository struct {
+	db *sql.DB
+}
+
+type StatementRepositoryInterface interface {
+	GetAllByUserID(userID int64) ([]*model.Statement, error)
+	GetByMonth(userID int64, month time.Time) (*model.Statement, error)
+	Upsert(statement *model.Statement) error
+}
+
+func NewStatementRepository(db *sql.DB) *StatementRepository {
+	return &StatementRepository{db: db}
+}
+
+func (r *StatementRepository) GetAllByUserID(userID int64) ([]*model.Statement, error) {
+	query := `
+	SELECT
+		id,
+		created_at,
+		month,
+		octet_length(content)
+	FROM statements
+	WHERE user_id = $1
+	ORDER BY month DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	statements := []*model.Statement{}
+
+	for rows.Next() {
+		statement := &model.Statement{User: &model.User{ID: userID}}
+
+		err := rows.Scan(
+			&statement.ID,
+			&statement.CreatedAt,
+			&statement.Month,
+			&statement.Size,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, statement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return statements, nil
+}
+
+func (r *StatementRepository) GetByMonth(userID int64, month time.Time) (*model.Statement, error) {
+	query := `
+	SELECT
+		id,
+		created_at,
+		month,
+		content
+	FROM statements
+	WHERE
+		user_id = $1
+		AND month = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	statement := &model.Statement{User: &model.User{ID: userID}}
+
+	err := r.db.QueryRowContext(ctx, query, userID, month).Scan(
+		&statement.ID,
+		&statement.CreatedAt,
+		&statement.Month,
+		&statement.Content,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	statement.Size = len(statement.Content)
+	return statement, nil
+}
+
+func (r *StatementRepository) Upsert(statement *model.Statement) error {
+	query := `
+	INSERT INTO statements (user_id, month, content)
+	VALUES ($1, $2, $3)
+	ON CONFLICT ON CONSTRAINT unique_user_statement
+	DO UPDATE SET
+		content = EXCLUDED.content,
+		created_at = NOW()
+	RETURNING id, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	statement.Size = len(statement.Content)
+
+	return r.db.QueryRowContext(ctx, query, statement.User.ID, statement.Month, statement.Content).Scan(
+		&statement.ID,
+		&statement.CreatedAt,
+	)
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	e "financas/utils/errors"
+	"fmt"
+	"time"
+)
+
+type SubscriptionRepository struct {
+	db *sql.DB
+}
+
+type SubscriptionRepositoryInterface interface {
+	GetAllByUserID(userID int64, status model.SubscriptionStatus) ([]*model.Subscription, error)
+	GetByID(id, userID int64) (*model.Subscription, error)
+	Upsert(subscription *model.Subscription) error
+	Delete(id, userID int64) error
+}
+
+func NewSubscriptionRepository(db *sql.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+const sqlSelectSubscription = `
+	SELECT
+		s.id,
+		s.created_at,
+		s.merchant,
+		s.description,
+		s.cadence,
+		s.amount,
+		s.currency,
+		s.last_charge,
+		s.next_charge,
+		s.status,
+		s.version,
+		c.id,
+		c.created_at,
+		c.name,
+		c.type,
+		c.color,
+		c.version
+	FROM subscriptions s
+	LEFT JOIN categories c ON (s.category_id = c.id AND c.deleted = false)
+`
+
+type subscriptionScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(row subscriptionScanner, userID int64) (*model.Subscription, error) {
+	var (
+		categoryID        sql.NullInt64
+		categoryCreatedAt sql.NullTime
+		categoryName      sql.NullString
+		categoryType      sql.NullInt64
+		categoryColor     sql.NullString
+		categoryVersion   sql.NullInt64
+	)
+
+	subscription := &model.Subscription{User: &model.User{ID: userID}}
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.CreatedAt,
+		&subscription.Merchant,
+		&subscription.Description,
+		&subscription.Cadence,
+		&subscription.Amount,
+		&subscription.Currency,
+		&subscription.LastCharge,
+		&subscription.NextCharge,
+		&subscription.Status,
+		&subscription.Version,
+		&categoryID,
+		&categoryCreatedAt,
+		&categoryName,
+		&categoryType,
+		&categoryColor,
+		&categoryVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if categoryID.Valid {
+		subscription.Category = &model.Category{
+			ID:        categoryID.Int64,
+			CreatedAt: categoryCreatedAt.Time,
+			Name:      categoryName.String,
+			Type:      model.TypeCategoria(categoryType.Int64),
+			Color:     categoryColor.String,
+			Version:   int(categoryVersion.Int64),
+			User:      &model.User{ID: userID},
+		}
+	}
+
+	return subscription, nil
+}
+
+func (r *SubscriptionRepository) GetAllByUserID(userID int64, status model.SubscriptionStatus) ([]*model.Subscription, error) {
+	query := fmt.Sprintf(`
+	%s
+	WHERE
+		s.user_id = $1
+		AND ($2 = 0 OR s.status = $2)
+	ORDER BY s.next_charge ASC, s.id ASC
+	`, sqlSelectSubscription)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	subscriptions := []*model.Subscription{}
+
+	for rows.Next() {
+		subscription, err := scanSubscription(rows, userID)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *SubscriptionRepository) GetByID(id, userID int64) (*model.Subscription, error) {
+	query := fmt.Sprintf(`
+	%s
+	WHERE
+		s.id = $1
+		AND s.user_id = $2
+	`, sqlSelectSubscription)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	subscription, err := scanSubscription(r.db.QueryRowContext(ctx, query, id, userID), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, e.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return subscription, nil
+}
+
+func (r *SubscriptionRepository) Upsert(subscription *model.Subscription) error {
+	query := `
+	INSERT INTO subscriptions (
+		user_id,
+		category_id,
+		merchant,
+		description,
+		cadence,
+		amount,
+		currency,
+		last_charge,
+		next_charge,
+		status
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT ON CONSTRAINT unique_user_subscription
+	DO UPDATE SET
+		category_id = EXCLUDED.category_id,
+		description = EXCLUDED.description,
+		cadence = EXCLUDED.cadence,
+		amount = EXCLUDED.amount,
+		currency = EXCLUDED.currency,
+		last_charge = EXCLUDED.last_charge,
+		next_charge = EXCLUDED.next_charge,
+		status = EXCLUDED.status,
+		version = subscriptions.version + 1
+	RETURNING id, created_at, version
+	`
+
+	categoryID := sql.NullInt64{}
+	if subscription.Category != nil {
+		categoryID = sql.NullInt64{Int64: subscription.Category.ID, Valid: true}
+	}
+
+	args := []any{
+		subscription.User.ID,
+		categoryID,
+		subscription.Merchant,
+		subscription.Description,
+		subscription.Cadence,
+		subscription.Amount,
+		subscription.Currency,
+		subscription.LastCharge,
+		subscription.NextCharge,
+		subscription.Status,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return r.db.QueryRowContext(ctx, query, args...).Scan(
+		&subscription.ID,
+		&subscription.CreatedAt,
+		&subscription.Version,
+	)
+}
+
+func (r *SubscriptionRepository) Delete(id, userID int64) error {
+	query := `
+	DELETE FROM subscriptions
+	WHERE id = $1 AND user_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return e.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -18,9 +18,9 @@ type TransactionRepository struct {
 type TransactionRepositoryInterface interface {
 	GetAllByUserAndCategory(description string, userID int64, categoryID int64, startDate, endDate *time.Time, f filters.Filters) ([]*model.Transaction, filters.Metadata, error)
 	GetByID(id int64, userID int64) (*model.Transaction, error)
-	Insert(transaction *model.Transaction) error
-	Update(transaction *model.Transaction) error
-	Delete(id int64, userID int64) error
+	Insert(transaction *model.Transaction, tx *sql.Tx) error
+	Update(transaction *model.Transaction, tx *sql.Tx) error
+	Delete(id int64, userID int64, tx *sql.Tx) error
 }
 
 func NewTransactionRepository(db *sql.DB) *TransactionRepository {
@@ -40,6 +40,7 @@ func (r *TransactionRepository) GetAllByUserAndCategory(description string, user
 	t.category_id, 
 	t.description, 
 	t.amount,
+	t.currency,
 	c.created_at as c_created_at,
 	c.name,
 	c.type,
@@ -107,6 +108,7 @@ func (r *TransactionRepository) GetAllByUserAndCategory(description string, user
 			&transaction.Category.ID,
 			&transaction.Description,
 			&transaction.Amount,
+			&transaction.Currency,
 			&transaction.Category.CreatedAt,
 			&transaction.Category.Name,
 			&transaction.Category.Type,
@@ -126,14 +128,15 @@ func (r *TransactionRepository) GetAllByUserAndCategory(description string, user
 func (r *TransactionRepository) GetByID(id int64, userID int64) (*model.Transaction, error) {
 	query := `
 	SELECT 
-		id, 
-		created_at, 
-		deleted, 
-		version, 
-		user_id, 
-		category_id, 
-		description, 
-		amount,
+		t.id, 
+		t.created_at, 
+		t.deleted, 
+		t.version, 
+		t.user_id, 
+		t.category_id, 
+		t.description, 
+		t.amount,
+		t.currency,
 		c.created_at as c_created_at,
 		c.name,
 		c.type,
@@ -142,12 +145,12 @@ func (r *TransactionRepository) GetByID(id int64, userID int64) (*model.Transact
 		c.version
 	FROM transactions t
 	INNER JOIN categories c ON (t.category_id = c.id)
-	WHERE id = $1 AND user_id = $2 AND deleted = false
+	WHERE t.id = $1 AND t.user_id = $2 AND t.deleted = false
 	`
 
 	var tx model.Transaction
 	tx.User = &model.User{}
-	tx.Category = &model.Category{}
+	tx.Category = &model.Category{User: &model.User{}}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -161,6 +164,7 @@ func (r *TransactionRepository) GetByID(id int64, userID int64) (*model.Transact
 		&tx.Category.ID,
 		&tx.Description,
 		&tx.Amount,
+		&tx.Currency,
 		&tx.Category.CreatedAt,
 		&tx.Category.Name,
 		&tx.Category.Type,
@@ -181,15 +185,16 @@ func (r *TransactionRepository) GetByID(id int64, userID int64) (*model.Transact
 	return &tx, nil
 }
 
-func (r *TransactionRepository) Insert(transaction *model.Transaction) error {
+func (r *TransactionRepository) Insert(transaction *model.Transaction, tx *sql.Tx) error {
 	query := `
 	INSERT INTO transactions ( 
 			user_id, 
 			category_id, 
 			description, 
-			amount
+			amount,
+			currency
 	)
-	VALUES ($1, $2, $3, $4)
+	VALUES ($1, $2, $3, $4, $5)
 	RETURNING id,created_at, version
 	`
 
@@ -198,12 +203,13 @@ func (r *TransactionRepository) Insert(transaction *model.Transaction) error {
 		transaction.Category.ID,
 		transaction.Description,
 		transaction.Amount,
+		transaction.Currency,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+	err := tx.QueryRowContext(ctx, query, args...).Scan(
 		&transaction.ID,
 		&transaction.CreatedAt,
 		&transaction.Version,
@@ -215,19 +221,20 @@ func (r *TransactionRepository) Insert(transaction *model.Transaction) error {
 	return nil
 }
 
-func (r *TransactionRepository) Update(transaction *model.Transaction) error {
+func (r *TransactionRepository) Update(transaction *model.Transaction, tx *sql.Tx) error {
 	query := `
 	UPDATE transactions
 	SET user_id = $1, 
 		category_id = $2, 
 		description = $3, 
 		amount = $4, 
+		currency = $5,
 		version = version + 1
 	WHERE 
-		id = $5
-		AND user_id = $6
+		id = $6
+		AND user_id = $7
 		AND deleted = false 
-		AND version = $7
+		AND version = $8
 	RETURNING version
 	`
 
@@ -236,6 +243,7 @@ func (r *TransactionRepository) Update(transaction *model.Transaction) error {
 		transaction.Category.ID,
 		transaction.Description,
 		transaction.Amount,
+		transaction.Currency,
 		transaction.ID,
 		transaction.User.ID,
 		transaction.Version,
@@ -244,7 +252,7 @@ func (r *TransactionRepository) Update(transaction *model.Transaction) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+	err := tx.QueryRowContext(ctx, query, args...).Scan(
 		&transaction.Version,
 	)
 
@@ -259,7 +267,7 @@ func (r *TransactionRepository) Update(transaction *model.Transaction) error {
 	return nil
 }
 
-func (r *TransactionRepository) Delete(id int64, userID int64) error {
+func (r *TransactionRepository) Delete(id int64, userID int64, tx *sql.Tx) error {
 	query := `
 	UPDATE transactions
 	SET 
@@ -273,7 +281,7 @@ func (r *TransactionRepository) Delete(id int64, userID int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, query, id, userID)
+	result, err := tx.ExecContext(ctx, query, id, userID)
 
 	if err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"financas/internal/model"
+	"financas/internal/model/filters"
 	e "financas/utils/errors"
 	"fmt"
 	"time"
@@ -20,6 +21,8 @@ type UserRepository interface {
 	GetByCodAndEmail(cod int, email string) (*model.User, error)
 	GetByID(id int64) (*model.User, error)
 	GetByEmail(email string) (*model.User, error)
+	GetAll(name string, f filters.Filters) ([]*model.User, filters.Metadata, error)
+	GetActivatedIDs() ([]int64, error)
 	Insert(user *model.User) error
 	UpdateCodByEmail(user *model.User) error
 	Update(user *model.User) error
@@ -36,6 +39,9 @@ const SqlSelectUser = `
 		cod, 
 		password_hash, 
 		activated, 
+		disabled,
+		role,
+		base_currency,
 		version
 	FROM users
 `
@@ -58,6 +64,9 @@ func (r *UserRepositoryDB) getUserByQuery(query string, args ...any) (*model.Use
 		&user.Cod,
 		&user.Password.Hash,
 		&user.Activated,
+		&user.Disabled,
+		&user.Role,
+		&user.BaseCurrency,
 		&user.Version,
 	)
 
@@ -78,6 +87,7 @@ func (r *UserRepositoryDB) GetByCodAndEmail(cod int, email string) (*model.User,
 	WHERE 
 		email = $1 
 		AND deleted = false 
+		AND disabled = false
 		AND cod = $2
 	`, SqlSelectUser)
 
@@ -104,11 +114,111 @@ func (r *UserRepositoryDB) GetByEmail(email string) (*model.User, error) {
 	return r.getUserByQuery(query, email)
 }
 
+func (r *UserRepositoryDB) GetAll(name string, f filters.Filters) ([]*model.User, filters.Metadata, error) {
+	query := fmt.Sprintf(`
+	SELECT 
+		count(*) OVER(),
+		id, 
+		created_at, 
+		name, 
+		phone, 
+		email, 
+		activated, 
+		disabled,
+		role,
+		version
+	FROM users
+	WHERE 
+		(to_tsvector('simple', name) @@ plainto_tsquery('simple', $1) OR $1 = '')
+		AND deleted = false
+	ORDER BY %s %s, id ASC
+	LIMIT $2 OFFSET $3
+	`, f.SortColumn(), f.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, name, f.Limit(), f.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	users := []*model.User{}
+
+	for rows.Next() {
+		var user model.User
+		err := rows.Scan(
+			&totalRecords,
+			&user.ID,
+			&user.CreatedAt,
+			&user.Name,
+			&user.Phone,
+			&user.Email,
+			&user.Activated,
+			&user.Disabled,
+			&user.Role,
+			&user.Version,
+		)
+		if err != nil {
+			return nil, filters.Metadata{}, err
+		}
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metaData := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return users, metaData, nil
+}
+
+func (r *UserRepositoryDB) GetActivatedIDs() ([]int64, error) {
+	query := `
+	SELECT id
+	FROM users
+	WHERE
+		activated = true
+		AND disabled = false
+		AND deleted = false
+	ORDER BY id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := []int64{}
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func (r *UserRepositoryDB) Insert(user *model.User) error {
 	query := `
 	INSERT INTO users (name, email, phone,cod, password_hash, activated,deleted)
 	VALUES ($1, $2, $3, $4, $5, $6,false)
-	RETURNING id, created_at, version
+	RETURNING id, created_at, role, base_currency, version
 	`
 	args := []any{
 		user.Name,
@@ -125,6 +235,8 @@ func (r *UserRepositoryDB) Insert(user *model.User) error {
 	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&user.ID,
 		&user.CreatedAt,
+		&user.Role,
+		&user.BaseCurrency,
 		&user.Version,
 	)
 
@@ -183,10 +295,12 @@ func (r *UserRepositoryDB) Update(user *model.User) error {
 		phone = $4, 
 		password_hash = $5,
 		activated = $6,
+		disabled = $7,
+		base_currency = $8,
 		version = version + 1
 	WHERE 
-		id = $7 
-		AND version = $8
+		id = $9 
+		AND version = $10
 	RETURNING version`
 
 	args := []any{
@@ -196,6 +310,8 @@ func (r *UserRepositoryDB) Update(user *model.User) error {
 		user.Phone,
 		user.Password.Hash,
 		user.Activated,
+		user.Disabled,
+		user.BaseCurrency,
 		user.ID,
 		user.Version,
 	}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+	"financas/internal/model"
+
+	"github.com/go-chi/chi"
+)
+
+type AdminRouter struct {
+	handler handler.AdminHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewAdminRouter(h handler.AdminHandlerInterface, m middleware.MiddlewareInterface) *AdminRouter {
+	return &AdminRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type AdminRouterInterface interface {
+	AdminRoutes(r chi.Router)
+}
+
+func (a *AdminRouter) AdminRoutes(r chi.Router) {
+	r.Route("/admin", func(r chi.Router) {
+		r.With(a.m.RequirePermission(model.PermissionUsersRead)).Get("/users", a.handler.GetAllUsers)
+		r.With(a.m.RequirePermission(model.PermissionUsersWrite)).Patch("/users/{id}/deactivate", a.handler.DeactivateUser)
+		r.With(a.m.RequirePermission(model.PermissionUsersWrite)).Patch("/users/{id}/reactivate", a.handler.ReactivateUser)
+		r.With(a.m.RequirePermission(model.PermissionStatsRead)).Get("/stats", a.handler.GetSystemStats)
+	})
+}
@@ -0,0 +1,38 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type AssetRouter struct {
+	handler handler.AssetHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewAssetRouter(h handler.AssetHandlerInterface, m middleware.MiddlewareInterface) *AssetRouter {
+	return &AssetRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type AssetRouterInterface interface {
+	AssetRoutes(r chi.Router)
+}
+
+func (router *AssetRouter) AssetRoutes(r chi.Router) {
+	r.Route("/assets", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetAll)
+		r.Get("/{id}", router.handler.GetByID)
+		r.Post("/", router.handler.Create)
+		r.Put("/{id}", router.handler.Update)
+		r.Delete("/{id}", router.handler.Delete)
+		r.Post("/{id}/valuations", router.handler.AddValuation)
+		r.Delete("/{id}/valuations/{valuationID}", router.handler.DeleteValuation)
+	})
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type ChartRouter struct {
+	handler handler.ChartHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewChartRouter(h handler.ChartHandlerInterface, m middleware.MiddlewareInterface) *ChartRouter {
+	return &ChartRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type ChartRouterInterface interface {
+	ChartRoutes(r chi.Router)
+}
+
+func (router *ChartRouter) ChartRoutes(r chi.Router) {
+	r.Route("/charts", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/categories", router.handler.GetCategoryChart)
+		r.Get("/trends", router.handler.GetTrendChart)
+		r.Get("/goals/{id}", router.handler.GetGoalChart)
+	})
+}
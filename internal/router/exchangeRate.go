@@ -0,0 +1,37 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type ExchangeRateRouter struct {
+	handler handler.ExchangeRateHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewExchangeRateRouter(h handler.ExchangeRateHandlerInterface, m middleware.MiddlewareInterface) *ExchangeRateRouter {
+	return &ExchangeRateRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type ExchangeRateRouterInterface interface {
+	ExchangeRateRoutes(r chi.Router)
+}
+
+func (router *ExchangeRateRouter) ExchangeRateRoutes(r chi.Router) {
+	r.Route("/exchange-rates", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/{id}", router.handler.GetByID)
+		r.Get("/", router.handler.GetAll)
+		r.Post("/", router.handler.Create)
+		r.Post("/import", router.handler.Import)
+		r.Put("/{id}", router.handler.Update)
+		r.Delete("/{id}", router.handler.Delete)
+	})
+}
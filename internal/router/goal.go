@@ -27,10 +27,18 @@ func (g *GoalRouter) GoalRoutes(r chi.Router) {
 	r.Route("/goals", func(r chi.Router) {
 		r.Use(g.m.RequireActivatedUser)
 
+		r.Get("/shared", g.goal.GetShared)
 		r.Get("/{id}", g.goal.GetById)
+		r.Get("/{id}/simulation", g.goal.Simulate)
+		r.Get("/{id}/history", g.goal.GetStatusHistory)
 		r.Get("/", g.goal.GetAll)
 		r.Post("/", g.goal.Create)
 		r.Put("/{id}", g.goal.Update)
 		r.Delete("/{id}", g.goal.Delete)
+		r.Post("/{id}/pause", g.goal.Pause)
+		r.Post("/{id}/resume", g.goal.Resume)
+		r.Post("/{id}/archive", g.goal.Archive)
+		r.Post("/{id}/reopen", g.goal.Reopen)
+		r.Post("/{id}/extend", g.goal.ExtendDeadline)
 	})
 }
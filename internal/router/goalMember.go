@@ -0,0 +1,46 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type GoalMemberRouter struct {
+	handler handler.GoalMemberHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewGoalMemberRouter(
+	h handler.GoalMemberHandlerInterface,
+	m middleware.MiddlewareInterface,
+) *GoalMemberRouter {
+	return &GoalMemberRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type GoalMemberRouterInterface interface {
+	GoalMemberRoutes(r chi.Router)
+}
+
+func (router *GoalMemberRouter) GoalMemberRoutes(r chi.Router) {
+	r.Route("/goal_members", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/{id}", router.handler.GetByGoalID)
+		r.Get("/{id}/contributors", router.handler.GetContributors)
+		r.Post("/", router.handler.Invite)
+		r.Delete("/{id}", router.handler.Delete)
+	})
+
+	r.Route("/goal_invites", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetInvites)
+		r.Post("/{id}/accept", router.handler.Accept)
+		r.Post("/{id}/decline", router.handler.Decline)
+	})
+}
@@ -0,0 +1,41 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type GoalMilestoneRouter struct {
+	handler handler.GoalMilestoneHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewGoalMilestoneRouter(h handler.GoalMilestoneHandlerInterface, m middleware.MiddlewareInterface) GoalMilestoneRouterInterface {
+	return &GoalMilestoneRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type GoalMilestoneRouterInterface interface {
+	GoalMilestoneRoutes(r chi.Router)
+}
+
+func (router *GoalMilestoneRouter) GoalMilestoneRoutes(r chi.Router) {
+	r.Route("/goal_milestones", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetByGoalID)
+		r.Post("/", router.handler.Create)
+		r.Put("/{id}", router.handler.Update)
+		r.Delete("/{id}", router.handler.Delete)
+	})
+
+	r.Route("/goal_timeline", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/{id}", router.handler.GetTimeline)
+	})
+}
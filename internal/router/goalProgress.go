@@ -31,7 +31,9 @@ func (g *GoalProgressRouter) GoalProgressRoutes(r chi.Router) {
 		r.Use(g.m.RequireActivatedUser)
 
 		r.Get("/{id}", g.handler.GetByGoalID)
+		r.Get("/{id}/summary", g.handler.GetMonthlySummary)
 		r.Post("/", g.handler.Create)
+		r.Post("/{id}/withdrawals", g.handler.Withdraw)
 		r.Put("/{id}", g.handler.Update)
 		r.Delete("/{id}", g.handler.Delete)
 	})
@@ -0,0 +1,38 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type GoalScheduleRouter struct {
+	handler handler.GoalScheduleHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewGoalScheduleRouter(h handler.GoalScheduleHandlerInterface, m middleware.MiddlewareInterface) GoalScheduleRouterInterface {
+	return &GoalScheduleRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type GoalScheduleRouterInterface interface {
+	GoalScheduleRoutes(r chi.Router)
+}
+
+func (router *GoalScheduleRouter) GoalScheduleRoutes(r chi.Router) {
+	r.Route("/goal_schedules", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetAll)
+		r.Post("/", router.handler.Create)
+		r.Get("/{id}", router.handler.GetByID)
+		r.Put("/{id}", router.handler.Update)
+		r.Delete("/{id}", router.handler.Delete)
+		r.Post("/{id}/pause", router.handler.Pause)
+		r.Post("/{id}/resume", router.handler.Resume)
+	})
+}
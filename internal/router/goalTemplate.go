@@ -0,0 +1,37 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type GoalTemplateRouter struct {
+	handler handler.GoalTemplateHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewGoalTemplateRouter(
+	h handler.GoalTemplateHandlerInterface,
+	m middleware.MiddlewareInterface,
+) *GoalTemplateRouter {
+	return &GoalTemplateRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type GoalTemplateRouterInterface interface {
+	GoalTemplateRoutes(r chi.Router)
+}
+
+func (router *GoalTemplateRouter) GoalTemplateRoutes(r chi.Router) {
+	r.Route("/goal_templates", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetCatalog)
+		r.Get("/{kind}", router.handler.Preview)
+		r.Post("/{kind}", router.handler.Instantiate)
+	})
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type InterestRateRouter struct {
+	handler handler.InterestRateHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewInterestRateRouter(h handler.InterestRateHandlerInterface, m middleware.MiddlewareInterface) InterestRateRouterInterface {
+	return &InterestRateRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type InterestRateRouterInterface interface {
+	InterestRateRoutes(r chi.Router)
+}
+
+func (router *InterestRateRouter) InterestRateRoutes(r chi.Router) {
+	r.Route("/interest-rates", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetAll)
+		r.Put("/", router.handler.Save)
+		r.Delete("/{id}", router.handler.Delete)
+	})
+}
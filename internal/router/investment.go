@@ -0,0 +1,43 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type InvestmentRouter struct {
+	handler handler.InvestmentHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewInvestmentRouter(h handler.InvestmentHandlerInterface, m middleware.MiddlewareInterface) *InvestmentRouter {
+	return &InvestmentRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type InvestmentRouterInterface interface {
+	InvestmentRoutes(r chi.Router)
+}
+
+func (router *InvestmentRouter) InvestmentRoutes(r chi.Router) {
+	r.Route("/investments", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetAll)
+		r.Post("/", router.handler.Create)
+		r.Get("/positions", router.handler.GetPositions)
+		r.Get("/returns", router.handler.GetReturns)
+		r.Get("/{id}", router.handler.GetByID)
+		r.Put("/{id}", router.handler.Update)
+		r.Delete("/{id}", router.handler.Delete)
+		r.Post("/{id}/operations", router.handler.AddOperation)
+		r.Delete("/{id}/operations/{operationID}", router.handler.DeleteOperation)
+		r.Post("/{id}/prices", router.handler.AddPrice)
+		r.Post("/{id}/prices/import", router.handler.ImportPrices)
+		r.Delete("/{id}/prices/{priceID}", router.handler.DeletePrice)
+	})
+}
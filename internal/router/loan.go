@@ -0,0 +1,41 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type LoanRouter struct {
+	handler handler.LoanHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewLoanRouter(h handler.LoanHandlerInterface, m middleware.MiddlewareInterface) *LoanRouter {
+	return &LoanRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type LoanRouterInterface interface {
+	LoanRoutes(r chi.Router)
+}
+
+func (router *LoanRouter) LoanRoutes(r chi.Router) {
+	r.Route("/loans", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetAll)
+		r.Post("/", router.handler.Create)
+		r.Post("/simulate", router.handler.Simulate)
+		r.Get("/{id}", router.handler.GetByID)
+		r.Put("/{id}", router.handler.Update)
+		r.Delete("/{id}", router.handler.Delete)
+		r.Post("/{id}/prepayments", router.handler.AddPrepayment)
+		r.Delete("/{id}/prepayments/{prepaymentID}", router.handler.DeletePrepayment)
+		r.Post("/{id}/installments/{number}/payment", router.handler.PayInstallment)
+		r.Delete("/{id}/installments/{number}/payment", router.handler.UnpayInstallment)
+	})
+}
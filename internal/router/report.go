@@ -31,5 +31,11 @@ func (router *ReportRouter) ReportRoutes(r chi.Router) {
 		r.Get("/categories", router.handler.GetCategoryReportHandler)
 		r.Get("/top-categories", router.handler.GetTopCategoriesHandler)
 		r.Get("/income-vs-expenses", router.handler.GetIncomeVsExpensesHandler)
+		r.Get("/forecast", router.handler.GetCashFlowForecastHandler)
+		r.Get("/comparison", router.handler.GetPeriodComparisonHandler)
+		r.Get("/time-series", router.handler.GetTimeSeriesHandler)
+		r.Get("/export.xlsx", router.handler.ExportXLSXHandler)
+		r.Get("/anomalies", router.handler.GetAnomaliesHandler)
+		r.Get("/net-worth", router.handler.GetNetWorthHandler)
 	})
 }
@@ -23,6 +23,19 @@ type Router struct {
 	goal           GoalRouterInterface
 	goalProgress   GoalProgressRouterInterface
 	report         ReportRouterInterface
+	admin          AdminRouterInterface
+	exchangeRate   ExchangeRateRouterInterface
+	statement      StatementRouterInterface
+	chart          ChartRouterInterface
+	subscription   SubscriptionRouterInterface
+	asset          AssetRouterInterface
+	loan           LoanRouterInterface
+	investment     InvestmentRouterInterface
+	goalSchedule   GoalScheduleRouterInterface
+	goalMilestone  GoalMilestoneRouterInterface
+	interestRate   InterestRateRouterInterface
+	goalMember     GoalMemberRouterInterface
+	goalTemplate   GoalTemplateRouterInterface
 	ErrResp        errors.ErrorResponseInterface
 	ContextGetUser func(r *http.Request) *model.User
 	ContextSetUser func(r *http.Request, user *model.User) *http.Request
@@ -46,6 +59,7 @@ func NewRouter(
 		contextSetUser,
 		h.Service.Auth,
 		h.Service.User,
+		h.Service.Permission,
 		config,
 	)
 	return &Router{
@@ -54,13 +68,26 @@ func NewRouter(
 		ContextSetUser: contextSetUser,
 		Handler:        h,
 		m:              m,
-		user:           NewUserRouter(h.User),
+		user:           NewUserRouter(h.User, m),
 		auth:           NewAuthRouter(h.Auth),
 		category:       NewCategoryRouter(h.Category, m),
 		transaction:    NewTransactionRouter(h.Transaction, m),
 		report:         NewReportRouter(h.Report, m),
 		goal:           NewGoalRouter(h.Goal, m),
 		goalProgress:   NewGoalProgressRouter(h.GoalProgress, m),
+		admin:          NewAdminRouter(h.Admin, m),
+		exchangeRate:   NewExchangeRateRouter(h.ExchangeRate, m),
+		statement:      NewStatementRouter(h.Statement, m),
+		chart:          NewChartRouter(h.Chart, m),
+		subscription:   NewSubscriptionRouter(h.Subscription, m),
+		asset:          NewAssetRouter(h.Asset, m),
+		loan:           NewLoanRouter(h.Loan, m),
+		investment:     NewInvestmentRouter(h.Investment, m),
+		goalSchedule:   NewGoalScheduleRouter(h.GoalSchedule, m),
+		goalMilestone:  NewGoalMilestoneRouter(h.GoalMilestone, m),
+		interestRate:   NewInterestRateRouter(h.InterestRate, m),
+		goalMember:     NewGoalMemberRouter(h.GoalMember, m),
+		goalTemplate:   NewGoalTemplateRouter(h.GoalTemplate, m),
 	}
 }
 
@@ -90,6 +117,19 @@ func (router *Router) RegisterRoutes() *chi.Mux {
 		router.report.ReportRoutes(r)
 		router.goal.GoalRoutes(r)
 		router.goalProgress.GoalProgressRoutes(r)
+		router.admin.AdminRoutes(r)
+		router.exchangeRate.ExchangeRateRoutes(r)
+		router.statement.StatementRoutes(r)
+		router.chart.ChartRoutes(r)
+		router.subscription.SubscriptionRoutes(r)
+		router.asset.AssetRoutes(r)
+		router.loan.LoanRoutes(r)
+		router.investment.InvestmentRoutes(r)
+		router.goalSchedule.GoalScheduleRoutes(r)
+		router.goalMilestone.GoalMilestoneRoutes(r)
+		router.interestRate.InterestRateRoutes(r)
+		router.goalMember.GoalMemberRoutes(r)
+		router.goalTemplate.GoalTemplateRoutes(r)
 
 		r.Route("/healthcheck", func(r chi.Router) {
 			r.Use(router.m.RequireActivatedUser)
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type StatementRouter struct {
+	handler handler.StatementHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewStatementRouter(h handler.StatementHandlerInterface, m middleware.MiddlewareInterface) *StatementRouter {
+	return &StatementRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type StatementRouterInterface interface {
+	StatementRoutes(r chi.Router)
+}
+
+func (router *StatementRouter) StatementRoutes(r chi.Router) {
+	r.Route("/statements", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetAll)
+		r.Get("/{month}", router.handler.Download)
+		r.Post("/{month}", router.handler.Generate)
+	})
+}
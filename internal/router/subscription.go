@@ -0,0 +1,36 @@
+package router
+
+import (
+	"financas/internal/handler"
+	"financas/internal/middleware"
+
+	"github.com/go-chi/chi"
+)
+
+type SubscriptionRouter struct {
+	handler handler.SubscriptionHandlerInterface
+	m       middleware.MiddlewareInterface
+}
+
+func NewSubscriptionRouter(h handler.SubscriptionHandlerInterface, m middleware.MiddlewareInterface) *SubscriptionRouter {
+	return &SubscriptionRouter{
+		handler: h,
+		m:       m,
+	}
+}
+
+type SubscriptionRouterInterface interface {
+	SubscriptionRoutes(r chi.Router)
+}
+
+func (router *SubscriptionRouter) SubscriptionRoutes(r chi.Router) {
+	r.Route("/subscriptions", func(r chi.Router) {
+		r.Use(router.m.RequireActivatedUser)
+
+		r.Get("/", router.handler.GetAll)
+		r.Get("/detected", router.handler.Detect)
+		r.Post("/confirm", router.handler.Confirm)
+		r.Post("/dismiss", router.handler.Dismiss)
+		r.Delete("/{id}", router.handler.Delete)
+	})
+}
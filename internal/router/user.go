@@ -2,17 +2,20 @@ package router
 
 import (
 	"financas/internal/handler"
+	"financas/internal/middleware"
 
 	"github.com/go-chi/chi"
 )
 
 type UserRouter struct {
 	User handler.UserHandlerInterface
+	m    middleware.MiddlewareInterface
 }
 
-func NewUserRouter(userHandler handler.UserHandlerInterface) *UserRouter {
+func NewUserRouter(userHandler handler.UserHandlerInterface, m middleware.MiddlewareInterface) *UserRouter {
 	return &UserRouter{
 		User: userHandler,
+		m:    m,
 	}
 }
 
@@ -24,5 +27,6 @@ func (u *UserRouter) UserRoutes(r chi.Router) {
 	r.Route("/users", func(r chi.Router) {
 		r.Post("/activate", u.User.ActivateUserHandler)
 		r.Post("/", u.User.CreateUserHandler)
+		r.With(u.m.RequireActivatedUser).Put("/base-currency", u.User.UpdateBaseCurrencyHandler)
 	})
 }
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+)
+
+type AdminService struct {
+	user  repository.UserRepository
+	admin repository.AdminRepositoryInterface
+}
+
+type AdminServiceInterface interface {
+	GetAllUsers(v *validator.Validator, name string, f filters.Filters) ([]*model.User, filters.Metadata, error)
+	SetUserActivated(id, adminID int64, activated bool) (*model.User, error)
+	GetSystemStats() (*model.SystemStats, error)
+}
+
+func NewAdminService(user repository.UserRepository, admin repository.AdminRepositoryInterface) *AdminService {
+	return &AdminService{
+		user:  user,
+		admin: admin,
+	}
+}
+
+func (s *AdminService) GetAllUsers(v *validator.Validator, name string, f filters.Filters) ([]*model.User, filters.Metadata, error) {
+	if filters.ValidateFilters(v, f); !v.Valid() {
+		return nil, filters.Metadata{}, e.ErrInvalidData
+	}
+
+	return s.user.GetAll(name, f)
+}
+
+func (s *AdminService) SetUserActivated(id, adminID int64, activated bool) (*model.User, error) {
+	if id == adminID {
+		return nil, e.ErrNotPermitted
+	}
+
+	user, err := s.user.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Disabled = !activated
+
+	err = s.user.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *AdminService) GetSystemStats() (*model.SystemStats, error) {
+	return s.admin.GetSystemStats()
+}
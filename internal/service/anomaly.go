@@ -0,0 +1,219 @@
+package service
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	anomalyMinMonths       = 3
+	anomalyMinTransactions = 5
+	anomalyFlatMargin      = 0.5
+	anomalyZScoreFactor    = 0.6745
+)
+
+type AnomalyService struct {
+	report repository.ReportRepositoryInterface
+}
+
+type AnomalyServiceInterface interface {
+	GetAnomalies(v *validator.Validator, userID int64, month *time.Time, lookback int, threshold float64) (*model.AnomalyReport, error)
+}
+
+func NewAnomalyService(report repository.ReportRepositoryInterface) *AnomalyService {
+	return &AnomalyService{
+		report: report,
+	}
+}
+
+type categoryHistory struct {
+	category     *model.Category
+	firstMonth   time.Time
+	monthly      map[time.Time]money.Money
+	baseline     []money.Money
+	transactions []*model.Transaction
+}
+
+func (s *AnomalyService) GetAnomalies(
+	v *validator.Validator,
+	userID int64,
+	month *time.Time,
+	lookback int,
+	threshold float64,
+) (*model.AnomalyReport, error) {
+	v.Check(lookback >= anomalyMinMonths, "months", fmt.Sprintf("must be at least %d", anomalyMinMonths))
+	v.Check(lookback <= 24, "months", "must be a maximum of 24")
+	v.Check(threshold > 0, "threshold", "must be greater than zero")
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if month != nil {
+		start = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	v.Check(start.Before(now), "month", "must not be in the future")
+
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	end := start.AddDate(0, 1, 0)
+	baselineStart := start.AddDate(0, -lookback, 0)
+
+	transactions, currency, err := s.report.GetConvertedTransactions(userID, baselineStart, end)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make(map[int64]*categoryHistory)
+	ids := []int64{}
+
+	for _, t := range transactions {
+		if t.Category.Type != model.DESPESA {
+			continue
+		}
+
+		h, exist := histories[t.Category.ID]
+		if !exist {
+			h = &categoryHistory{
+				category: t.Category,
+				monthly:  make(map[time.Time]money.Money),
+			}
+			histories[t.Category.ID] = h
+			ids = append(ids, t.Category.ID)
+		}
+
+		m := time.Date(t.CreatedAt.Year(), t.CreatedAt.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if h.firstMonth.IsZero() || m.Before(h.firstMonth) {
+			h.firstMonth = m
+		}
+
+		h.monthly[m] = h.monthly[m].Add(t.Amount)
+
+		if m.Before(start) {
+			h.baseline = append(h.baseline, t.Amount)
+		} else {
+			h.transactions = append(h.transactions, t)
+		}
+	}
+
+	report := &model.AnomalyReport{
+		Currency:       currency,
+		Month:          start.Format(model.StatementMonthLayout),
+		LookbackMonths: lookback,
+		Threshold:      threshold,
+		Anomalies:      []model.Anomaly{},
+	}
+
+	for _, id := range ids {
+		h := histories[id]
+
+		totals := []money.Money{}
+		for m := h.firstMonth; m.Before(start); m = m.AddDate(0, 1, 0) {
+			totals = append(totals, h.monthly[m])
+		}
+
+		if len(totals) >= anomalyMinMonths {
+			current := h.monthly[start]
+			median, mad := medianMoney(totals), medianAbsoluteDeviation(totals)
+
+			if score, flagged := isAnomalous(current, median, mad, threshold); flagged {
+				report.Anomalies = append(report.Anomalies, model.Anomaly{
+					Kind:     model.AnomalyMonthlyTotal.String(),
+					Category: h.category.ToDTO(),
+					Amount:   current,
+					Baseline: model.AnomalyBaseline{Median: median, MAD: mad, Samples: len(totals)},
+					Score:    score,
+					Explanation: explainAnomaly(
+						fmt.Sprintf("Spent %s in %s in %s", current, h.category.Name, report.Month),
+						fmt.Sprintf("the monthly median over the previous %d months", len(totals)),
+						current, median, score, threshold,
+					),
+				})
+			}
+		}
+
+		if len(h.baseline) < anomalyMinTransactions {
+			continue
+		}
+
+		median, mad := medianMoney(h.baseline), medianAbsoluteDeviation(h.baseline)
+
+		for _, t := range h.transactions {
+			score, flagged := isAnomalous(t.Amount, median, mad, threshold)
+			if !flagged {
+				continue
+			}
+
+			transactionID, description, date := t.ID, t.Description, t.CreatedAt
+			report.Anomalies = append(report.Anomalies, model.Anomaly{
+				Kind:          model.AnomalyTransaction.String(),
+				Category:      h.category.ToDTO(),
+				TransactionID: &transactionID,
+				Description:   &description,
+				Date:          &date,
+				Amount:        t.Amount,
+				Baseline:      model.AnomalyBaseline{Median: median, MAD: mad, Samples: len(h.baseline)},
+				Score:         score,
+				Explanation: explainAnomaly(
+					fmt.Sprintf("Transaction %q of %s in %s", t.Description, t.Amount, h.category.Name),
+					fmt.Sprintf("the median transaction over the previous %d months", lookback),
+					t.Amount, median, score, threshold,
+				),
+			})
+		}
+	}
+
+	sort.SliceStable(report.Anomalies, func(i, j int) bool {
+		return report.Anomalies[i].Amount.Sub(report.Anomalies[i].Baseline.Median) >
+			report.Anomalies[j].Amount.Sub(report.Anomalies[j].Baseline.Median)
+	})
+
+	return report, nil
+}
+
+func isAnomalous(value, median, mad money.Money, threshold float64) (*float64, bool) {
+	if value <= median {
+		return nil, false
+	}
+
+	if mad.IsZero() {
+		if median.IsZero() {
+			return nil, value.IsPositive()
+		}
+		return nil, value > median.Add(median.MulRate(anomalyFlatMargin))
+	}
+
+	score := math.Round(anomalyZScoreFactor*float64(value.Sub(median))/float64(mad)*100) / 100
+	return &score, score > threshold
+}
+
+func explainAnomaly(subject, baseline string, value, median money.Money, score *float64, threshold float64) string {
+	explanation := fmt.Sprintf("%s, while %s was zero", subject, baseline)
+	if median.IsPositive() {
+		explanation = fmt.Sprintf("%s, %.1fx %s (%s)", subject, float64(value)/float64(median), baseline, median)
+	}
+
+	if score == nil {
+		return explanation + "; the baseline had no variation."
+	}
+
+	return fmt.Sprintf("%s; modified z-score %.2f is above the %.2f threshold.", explanation, *score, threshold)
+}
+
+func medianAbsoluteDeviation(values []money.Money) money.Money {
+	median := medianMoney(values)
+
+	deviations := make([]money.Money, 0, len(values))
+	for _, v := range values {
+		deviations = append(deviations, v.Sub(median).Abs())
+	}
+
+	return medianMoney(deviations)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"sort"
+	"time"
+)
+
+type AssetService struct {
+	asset        repository.AssetRepositoryInterface
+	report       repository.ReportRepositoryInterface
+	exchangeRate ExchangeRateServiceInterface
+}
+
+type AssetServiceInterface interface {
+	GetAll(v *validator.Validator, name string, userID int64, f filters.Filters) ([]*model.Asset, filters.Metadata, error)
+	GetByID(id, userID int64) (*model.Asset, error)
+	Insert(v *validator.Validator, asset *model.Asset) error
+	Update(v *validator.Validator, asset *model.Asset) error
+	Delete(id, userID int64) error
+	AddValuation(v *validator.Validator, assetID, userID int64, valuation *model.AssetValuation) error
+	DeleteValuation(id, assetID, userID int64) error
+	GetNetWorth(v *validator.Validator, userID int64, startDate, endDate *time.Time, granularity string) (*model.NetWorthReport, error)
+}
+
+func NewAssetService(
+	asset repository.AssetRepositoryInterface,
+	report repository.ReportRepositoryInterface,
+	exchangeRate ExchangeRateServiceInterface,
+) *AssetService {
+	return &AssetService{
+		asset:        asset,
+		report:       report,
+		exchangeRate: exchangeRate,
+	}
+}
+
+func (s *AssetService) GetAll(v *validator.Validator, name string, userID int64, f filters.Filters) ([]*model.Asset, filters.Metadata, error) {
+	if filters.ValidateFilters(v, f); !v.Valid() {
+		return nil, filters.Metadata{}, e.ErrInvalidData
+	}
+
+	return s.asset.GetAll(name, userID, f)
+}
+
+func (s *AssetService) GetByID(id, userID int64) (*model.Asset, error) {
+	asset, err := s.asset.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	asset.Valuations, err = s.asset.GetValuations(asset.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+func (s *AssetService) Insert(v *validator.Validator, asset *model.Asset) error {
+	if asset.ValidateAsset(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.asset.Insert(asset)
+}
+
+func (s *AssetService) Update(v *validator.Validator, asset *model.Asset) error {
+	if asset.ValidateAsset(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.asset.Update(asset)
+}
+
+func (s *AssetService) Delete(id, userID int64) error {
+	return s.asset.Delete(id, userID)
+}
+
+func (s *AssetService) AddValuation(v *validator.Validator, assetID, userID int64, valuation *model.AssetValuation) error {
+	if valuation.ValidateAssetValuation(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	if _, err := s.asset.GetByID(assetID, userID); err != nil {
+		return err
+	}
+
+	valuation.AssetID = assetID
+	return s.asset.UpsertValuation(valuation)
+}
+
+func (s *AssetService) DeleteValuation(id, assetID, userID int64) error {
+	if _, err := s.asset.GetByID(assetID, userID); err != nil {
+		return err
+	}
+
+	return s.asset.DeleteValuation(id, assetID)
+}
+
+func (s *AssetService) GetNetWorth(
+	v *validator.Validator,
+	userID int64,
+	startDate, endDate *time.Time,
+	granularity string,
+) (*model.NetWorthReport, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	start := time.Date(today.Year(), today.Month()-11, 1, 0, 0, 0, 0, time.UTC)
+	if startDate != nil {
+		start = *startDate
+	}
+
+	end := today.AddDate(0, 0, 1)
+	if endDate != nil {
+		end = endDate.AddDate(0, 0, 1)
+	}
+
+	v.Check(validator.In(granularity, model.Granularities...), "granularity", "invalid granularity value")
+	v.Check(start.Before(end), "start", e.ErrStartDateAfterEndDate.Error())
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	buckets := timeBuckets(granularity, start, end)
+	if v.Check(len(buckets) <= maxTimeSeriesPoints, "granularity", "too many points for the given period"); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	opening, currency, err := s.report.GetBalance(userID, start)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates, err := s.report.GetTrend(userID, &model.TrendRange{
+		Granularity: granularity,
+		Start:       start,
+		End:         end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := s.asset.GetAllWithValuations(userID, end)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := s.exchangeRate.GetConverter(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[time.Time]*model.PeriodTotal, len(aggregates.Periods))
+	for _, p := range aggregates.Periods {
+		totals[p.Start.UTC()] = p
+	}
+
+	report := &model.NetWorthReport{
+		Currency:    currency,
+		Granularity: granularity,
+		Breakdown:   []model.NetWorthClass{},
+		History:     make([]model.NetWorthPoint, 0, len(buckets)),
+	}
+
+	running := opening
+	for _, bucket := range buckets {
+		if p, exist := totals[bucket]; exist {
+			running = running.Add(p.Income).Sub(p.Expenses)
+		}
+
+		date := nextBucket(granularity, bucket)
+		if date.After(end) {
+			date = end
+		}
+		date = date.AddDate(0, 0, -1)
+
+		point := model.NetWorthPoint{
+			Date:               date.Format(model.DateLayout),
+			TransactionBalance: running,
+		}
+
+		for _, asset := range assets {
+			valuation, exist := asset.ValueAt(date)
+			if !exist {
+				continue
+			}
+
+			value, err := converter.Convert(valuation.Value, asset.Currency, date)
+			if err != nil {
+				return nil, err
+			}
+
+			if asset.Class.IsLiability() {
+				point.Liabilities = point.Liabilities.Add(value)
+			} else {
+				point.Assets = point.Assets.Add(value)
+			}
+		}
+
+		point.NetWorth = point.TransactionBalance.Add(point.Assets).Sub(point.Liabilities)
+		report.History = append(report.History, point)
+	}
+
+	last := end.AddDate(0, 0, -1)
+	report.Date = last.Format(model.DateLayout)
+	report.TransactionBalance = running
+
+	classes := make(map[model.AssetClass]*model.NetWorthClass)
+	for _, asset := range assets {
+		valuation, exist := asset.ValueAt(last)
+		if !exist {
+			continue
+		}
+
+		value, err := converter.Convert(valuation.Value, asset.Currency, last)
+		if err != nil {
+			return nil, err
+		}
+
+		class, exist := classes[asset.Class]
+		if !exist {
+			class = &model.NetWorthClass{
+				Class: asset.Class.String(),
+				Kind:  asset.Class.Kind(),
+				Items: []model.NetWorthItem{},
+			}
+			classes[asset.Class] = class
+		}
+
+		class.Total = class.Total.Add(value)
+		class.Items = append(class.Items, model.NetWorthItem{
+			AssetID: asset.ID,
+			Name:    asset.Name,
+			Value:   value,
+			Date:    valuation.Date.Format(model.DateLayout),
+		})
+
+		if asset.Class.IsLiability() {
+			report.TotalLiabilities = report.TotalLiabilities.Add(value)
+		} else {
+			report.TotalAssets = report.TotalAssets.Add(value)
+		}
+	}
+
+	for assetClass, class := range classes {
+		whole := report.TotalAssets
+		if assetClass.IsLiability() {
+			whole = report.TotalLiabilities
+		}
+		class.Percentage = money.Percent(class.Total, whole)
+		report.Breakdown = append(report.Breakdown, *class)
+	}
+
+	sort.Slice(report.Breakdown, func(i, j int) bool {
+		if report.Breakdown[i].Kind != report.Breakdown[j].Kind {
+			return report.Breakdown[i].Kind == "ASSET"
+		}
+		return report.Breakdown[i].Total > report.Breakdown[j].Total
+	})
+
+	report.NetWorth = report.TransactionBalance.Add(report.TotalAssets).Sub(report.TotalLiabilities)
+
+	return report, nil
+}
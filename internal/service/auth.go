@@ -46,7 +46,7 @@ func (s *AuthService) Login(v *validator.Validator, email, password string) (str
 		}
 	}
 
-	if !user.Activated {
+	if !user.Activated || user.Disabled {
 		return "", e.ErrInactiveAccount
 	}
 
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"financas/internal/chart"
+	"financas/internal/model"
+	"financas/internal/model/money"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"time"
+)
+
+type ChartService struct {
+	report ReportServiceInterface
+	goal   GoalServiceInterface
+}
+
+type ChartServiceInterface interface {
+	GetCategoryChart(v *validator.Validator, userID int64, startDate, endDate *time.Time, categoryType string) ([]byte, error)
+	GetTrendChart(v *validator.Validator, userID int64) ([]byte, error)
+	GetGoalChart(v *validator.Validator, userID, goalID int64) ([]byte, error)
+}
+
+func NewChartService(report ReportServiceInterface, goal GoalServiceInterface) *ChartService {
+	return &ChartService{
+		report: report,
+		goal:   goal,
+	}
+}
+
+func (s *ChartService) GetCategoryChart(
+	v *validator.Validator,
+	userID int64,
+	startDate, endDate *time.Time,
+	categoryType string,
+) ([]byte, error) {
+	if v.Check(model.TypeCategoriaFromString(categoryType) != 0, "type", "must be RECEITA or DESPESA"); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	summary, err := s.report.GetFinancialSummary(v, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	total := summary.TotalExpenses
+	title := "Despesas por categoria"
+	if model.TypeCategoriaFromString(categoryType) == model.RECEITA {
+		total = summary.TotalIncome
+		title = "Receitas por categoria"
+	}
+
+	slices := []chart.Slice{}
+	for _, c := range summary.CategorySummary {
+		if *c.Category.Type != categoryType {
+			continue
+		}
+
+		slices = append(slices, chart.Slice{
+			Label: *c.Category.Name,
+			Value: c.Total.Float64(),
+			Color: *c.Category.Color,
+		})
+	}
+
+	return chart.Donut(title, formatAmount(total, summary.Currency), slices), nil
+}
+
+func (s *ChartService) GetTrendChart(v *validator.Validator, userID int64) ([]byte, error) {
+	summary, err := s.report.GetFinancialSummary(v, userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	series := []chart.Series{
+		{Name: "Receitas", Color: "#59a14f"},
+		{Name: "Despesas", Color: "#e15759"},
+	}
+
+	groups := make([]chart.BarGroup, 0, len(summary.MonthlyTrends))
+	for _, t := range summary.MonthlyTrends {
+		groups = append(groups, chart.BarGroup{
+			Label:  t.Month,
+			Values: []float64{t.Income.Float64(), t.Expenses.Float64()},
+		})
+	}
+
+	return chart.Bars(fmt.Sprintf("Receitas x despesas (%s)", summary.Currency), series, groups), nil
+}
+
+func (s *ChartService) GetGoalChart(v *validator.Validator, userID, goalID int64) ([]byte, error) {
+	goal, err := s.goal.GetById(v, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	caption := fmt.Sprintf(
+		"%s de %s (%.2f%%)",
+		formatAmount(goal.Current, goal.Currency),
+		formatAmount(goal.Amount, goal.Currency),
+		money.Percent(goal.Current, goal.Amount),
+	)
+
+	return chart.Progress(goal.Name, goal.Current.Float64(), goal.Amount.Float64(), caption, goal.Color), nil
+}
+
+func formatAmount(m money.Money, currency string) string {
+	return fmt.Sprintf("%s %s", currency, m.String())
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ExchangeRateService struct {
+	rate repository.ExchangeRateRepositoryInterface
+	user repository.UserRepository
+	db   *sql.DB
+}
+
+type ExchangeRateServiceInterface interface {
+	GetAll(v *validator.Validator, currency string, userID int64, f filters.Filters) ([]*model.ExchangeRate, filters.Metadata, error)
+	GetByID(id, userID int64) (*model.ExchangeRate, error)
+	Insert(v *validator.Validator, rate *model.ExchangeRate) error
+	Update(v *validator.Validator, rate *model.ExchangeRate) error
+	Delete(id, userID int64) error
+	Import(v *validator.Validator, userID int64, r io.Reader) ([]*model.ExchangeRate, error)
+	GetConverter(userID int64) (*CurrencyConverter, error)
+}
+
+func NewExchangeRateService(rate repository.ExchangeRateRepositoryInterface, user repository.UserRepository, db *sql.DB) *ExchangeRateService {
+	return &ExchangeRateService{
+		rate: rate,
+		user: user,
+		db:   db,
+	}
+}
+
+func (s *ExchangeRateService) GetAll(v *validator.Validator, currency string, userID int64, f filters.Filters) ([]*model.ExchangeRate, filters.Metadata, error) {
+	if filters.ValidateFilters(v, f); !v.Valid() {
+		return nil, filters.Metadata{}, e.ErrInvalidData
+	}
+
+	return s.rate.GetAll(model.NormalizeCurrency(currency), userID, f)
+}
+
+func (s *ExchangeRateService) GetByID(id, userID int64) (*model.ExchangeRate, error) {
+	return s.rate.GetByID(id, userID)
+}
+
+func (s *ExchangeRateService) Insert(v *validator.Validator, rate *model.ExchangeRate) error {
+	if rate.ValidateExchangeRate(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.rate.Insert(rate)
+}
+
+func (s *ExchangeRateService) Update(v *validator.Validator, rate *model.ExchangeRate) error {
+	if rate.ValidateExchangeRate(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.rate.Update(rate)
+}
+
+func (s *ExchangeRateService) Delete(id, userID int64) error {
+	return s.rate.Delete(id, userID)
+}
+
+func (s *ExchangeRateService) Import(v *validator.Validator, userID int64, r io.Reader) ([]*model.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	rates := []*model.ExchangeRate{}
+	line := 0
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		line++
+		key := fmt.Sprintf("line_%d", line)
+
+		if err != nil {
+			v.AddError(key, "must have the columns date, from_currency, to_currency and rate")
+			return nil, e.ErrInvalidData
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+
+		date, err := time.Parse(model.ExchangeRateDateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			v.AddError(key, "date must be in the format YYYY-MM-DD")
+			continue
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+		if err != nil {
+			v.AddError(key, "rate must be a number")
+			continue
+		}
+
+		rate := &model.ExchangeRate{
+			User:         &model.User{ID: userID},
+			FromCurrency: model.NormalizeCurrency(record[1]),
+			ToCurrency:   model.NormalizeCurrency(record[2]),
+			Rate:         value,
+			Date:         date,
+		}
+
+		lineValidator := validator.New()
+		if rate.ValidateExchangeRate(lineValidator); !lineValidator.Valid() {
+			for field, message := range lineValidator.Errors {
+				v.AddError(key, fmt.Sprintf("%s %s", field, message))
+			}
+			continue
+		}
+
+		rates = append(rates, rate)
+	}
+
+	v.Check(line > 0, "file", "must not be empty")
+
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	err := utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		for _, rate := range rates {
+			if err := s.rate.Upsert(rate, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (s *ExchangeRateService) GetConverter(userID int64) (*CurrencyConverter, error) {
+	user, err := s.user.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.rate.GetAllForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCurrencyConverter(user.BaseCurrency, rates), nil
+}
+
+type currencyPair struct {
+	from string
+	to   string
+}
+
+type CurrencyConverter struct {
+	Base  string
+	rates map[currencyPair][]*model.ExchangeRate
+}
+
+func NewCurrencyConverter(base string, rates []*model.ExchangeRate) *CurrencyConverter {
+	if base == "" {
+		base = model.DefaultCurrency
+	}
+
+	c := &CurrencyConverter{
+		Base:  base,
+		rates: make(map[currencyPair][]*model.ExchangeRate),
+	}
+
+	for _, rate := range rates {
+		pair := currencyPair{from: rate.FromCurrency, to: rate.ToCurrency}
+		c.rates[pair] = append(c.rates[pair], rate)
+	}
+
+	for _, list := range c.rates {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Date.Before(list[j].Date)
+		})
+	}
+
+	return c
+}
+
+func (c *CurrencyConverter) Convert(amount money.Money, currency string, date time.Time) (money.Money, error) {
+	if currency == "" || currency == c.Base {
+		return amount, nil
+	}
+
+	if rate := c.effectiveRate(currency, c.Base, date); rate != nil {
+		return amount.MulRate(rate.Rate), nil
+	}
+
+	if rate := c.effectiveRate(c.Base, currency, date); rate != nil {
+		return amount.DivRate(rate.Rate), nil
+	}
+
+	return 0, fmt.Errorf(
+		"%w: no %s to %s exchange rate effective on %s",
+		e.ErrExchangeRateNotFound,
+		currency,
+		c.Base,
+		date.Format(model.ExchangeRateDateLayout),
+	)
+}
+
+func (c *CurrencyConverter) effectiveRate(from, to string, date time.Time) *model.ExchangeRate {
+	list := c.rates[currencyPair{from: from, to: to}]
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	i := sort.Search(len(list), func(i int) bool {
+		return list[i].Date.After(day)
+	})
+
+	if i == 0 {
+		return nil
+	}
+
+	return list[i-1]
+}
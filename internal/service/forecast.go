@@ -0,0 +1,325 @@
+package service
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	recurringLookbackMonths = 6
+	recurringMinMonths      = 3
+	recurringAmountMargin   = 0.25
+	recurringMaxSilenceDays = 45
+)
+
+type ForecastService struct {
+	report       repository.ReportRepositoryInterface
+	goal         repository.GoalRepositoryInterface
+	exchangeRate ExchangeRateServiceInterface
+}
+
+type ForecastServiceInterface interface {
+	GetCashFlowForecast(v *validator.Validator, userID int64, days int, threshold money.Money) (*model.CashFlowForecast, error)
+	DetectRecurringTransactions(userID int64, now time.Time) ([]model.RecurringTransaction, string, error)
+}
+
+func NewForecastService(
+	report repository.ReportRepositoryInterface,
+	goal repository.GoalRepositoryInterface,
+	exchangeRate ExchangeRateServiceInterface,
+) *ForecastService {
+	return &ForecastService{
+		report:       report,
+		goal:         goal,
+		exchangeRate: exchangeRate,
+	}
+}
+
+func (s *ForecastService) GetCashFlowForecast(
+	v *validator.Validator,
+	userID int64,
+	days int,
+	threshold money.Money,
+) (*model.CashFlowForecast, error) {
+	v.Check(days > 0, "days", "must be greater than zero")
+	v.Check(days <= 365, "days", "must be a maximum of 365")
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	horizon := today.AddDate(0, 0, days)
+
+	balance, currency, err := s.report.GetBalance(userID, today.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	recurring, _, err := s.DetectRecurringTransactions(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(map[string][]model.ForecastEvent)
+
+	for _, r := range recurring {
+		eventType := model.ForecastEventRecurringExpense
+		if model.TypeCategoriaFromString(*r.Category.Type) == model.RECEITA {
+			eventType = model.ForecastEventRecurringIncome
+		}
+
+		for _, date := range monthlyDates(r.DayOfMonth, today, horizon, r.LastDate) {
+			key := date.Format(model.DateLayout)
+			events[key] = append(events[key], model.ForecastEvent{
+				Type:        eventType.String(),
+				Description: r.Description,
+				Amount:      r.Amount,
+			})
+		}
+	}
+
+	goalEvents, err := s.goalInstallmentEvents(userID, today, horizon)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, list := range goalEvents {
+		events[key] = append(events[key], list...)
+	}
+
+	forecast := &model.CashFlowForecast{
+		Currency:              currency,
+		StartingBalance:       balance,
+		Threshold:             threshold,
+		LowestBalance:         balance,
+		LowestBalanceDate:     today.Format(model.DateLayout),
+		AlertDates:            []string{},
+		RecurringTransactions: recurring,
+		Days:                  make([]model.ForecastDay, 0, days),
+	}
+
+	running := balance
+	for i := 1; i <= days; i++ {
+		date := today.AddDate(0, 0, i)
+		key := date.Format(model.DateLayout)
+
+		day := model.ForecastDay{
+			Date:   key,
+			Events: []model.ForecastEvent{},
+		}
+
+		for _, event := range events[key] {
+			if event.Type == model.ForecastEventRecurringIncome.String() {
+				day.Income = day.Income.Add(event.Amount)
+			} else {
+				day.Expenses = day.Expenses.Add(event.Amount)
+			}
+			day.Events = append(day.Events, event)
+		}
+
+		running = running.Add(day.Income).Sub(day.Expenses)
+		day.Balance = running
+		day.BelowThreshold = running < threshold
+
+		if day.BelowThreshold {
+			forecast.AlertDates = append(forecast.AlertDates, key)
+		}
+
+		if running < forecast.LowestBalance {
+			forecast.LowestBalance = running
+			forecast.LowestBalanceDate = key
+		}
+
+		forecast.Days = append(forecast.Days, day)
+	}
+
+	return forecast, nil
+}
+
+func (s *ForecastService) DetectRecurringTransactions(userID int64, now time.Time) ([]model.RecurringTransaction, string, error) {
+	start := time.Date(now.Year(), now.Month()-recurringLookbackMonths, 1, 0, 0, 0, 0, time.UTC)
+
+	transactions, currency, err := s.report.GetConvertedTransactions(userID, start, now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	groups := make(map[string][]*model.Transaction)
+	keys := []string{}
+
+	for _, t := range transactions {
+		key := recurringKey(t)
+		if _, exist := groups[key]; !exist {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+
+	recurring := []model.RecurringTransaction{}
+
+	for _, key := range keys {
+		group := groups[key]
+
+		months := make(map[string]bool)
+		amounts := make([]money.Money, 0, len(group))
+		daysOfMonth := make([]int, 0, len(group))
+
+		for _, t := range group {
+			months[t.CreatedAt.Format("2006-01")] = true
+			amounts = append(amounts, t.Amount)
+			daysOfMonth = append(daysOfMonth, t.CreatedAt.Day())
+		}
+
+		if len(months) < recurringMinMonths || len(months) != len(group) {
+			continue
+		}
+
+		last := group[len(group)-1]
+		if now.Sub(last.CreatedAt) > recurringMaxSilenceDays*24*time.Hour {
+			continue
+		}
+
+		median := medianMoney(amounts)
+		if !amountsAreSimilar(amounts, median) {
+			continue
+		}
+
+		recurring = append(recurring, model.RecurringTransaction{
+			Description: last.Description,
+			Category:    last.Category.ToDTO(),
+			Amount:      median,
+			DayOfMonth:  medianInt(daysOfMonth),
+			Occurrences: len(group),
+			LastDate:    last.CreatedAt,
+		})
+	}
+
+	return recurring, currency, nil
+}
+
+func (s *ForecastService) goalInstallmentEvents(userID int64, today, horizon time.Time) (map[string][]model.ForecastEvent, error) {
+	goals, err := s.goal.GetActiveByUserId(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(map[string][]model.ForecastEvent)
+	if len(goals) == 0 {
+		return events, nil
+	}
+
+	converter, err := s.exchangeRate.GetConverter(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, goal := range goals {
+		installments := goalInstallments(goal, today)
+		if installments == nil || installments.Quantity == 0 || !installments.Amount.IsPositive() {
+			continue
+		}
+
+		amount, err := converter.Convert(installments.Amount, goal.Currency, today)
+		if err != nil {
+			return nil, err
+		}
+
+		end := horizon
+		if goal.Deadline.Before(end) {
+			end = goal.Deadline
+		}
+
+		dates := monthlyDates(goal.Deadline.Day(), today, end, time.Time{})
+		if len(dates) > installments.Quantity {
+			dates = dates[:installments.Quantity]
+		}
+
+		goalID := goal.ID
+		for _, date := range dates {
+			key := date.Format(model.DateLayout)
+			events[key] = append(events[key], model.ForecastEvent{
+				Type:        model.ForecastEventGoalInstallment.String(),
+				Description: goal.Name,
+				Amount:      amount,
+				GoalID:      &goalID,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+func recurringKey(t *model.Transaction) string {
+	description := strings.ToLower(strings.Join(strings.Fields(t.Description), " "))
+	return fmt.Sprintf("%d|%s", t.Category.ID, description)
+}
+
+func monthlyDates(dayOfMonth int, after, until, last time.Time) []time.Time {
+	dates := []time.Time{}
+
+	for month := time.Date(after.Year(), after.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(until); month = month.AddDate(0, 1, 0) {
+		if !last.IsZero() && last.Year() == month.Year() && last.Month() == month.Month() {
+			continue
+		}
+
+		day := min(dayOfMonth, daysIn(month))
+		date := time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, time.UTC)
+
+		if date.After(after) && !date.After(until) {
+			dates = append(dates, date)
+		}
+	}
+
+	return dates
+}
+
+func daysIn(month time.Time) int {
+	return time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func medianMoney(values []money.Money) money.Money {
+	sorted := append([]money.Money(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return sorted[n/2-1].Add(sorted[n/2]).Div(2)
+}
+
+func medianInt(values []int) int {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	return sorted[(n-1)/2]
+}
+
+func amountsAreSimilar(amounts []money.Money, median money.Money) bool {
+	if !median.IsPositive() {
+		return false
+	}
+
+	margin := median.MulRate(recurringAmountMargin)
+	for _, amount := range amounts {
+		if amount.Sub(median).Abs() > margin {
+			return false
+		}
+	}
+	return true
+}
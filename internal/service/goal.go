@@ -1,29 +1,44 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"financas/internal/model"
 	"financas/internal/model/filters"
+	"financas/internal/model/money"
 	"financas/internal/repository"
+	"financas/utils"
 	e "financas/utils/errors"
 	"financas/utils/validator"
+	"fmt"
+	"math"
 	"time"
 )
 
 type GoalService struct {
-	Goal repository.GoalRepositoryInterface
+	Goal  repository.GoalRepositoryInterface
+	rates repository.InterestRateRepositoryInterface
+	db    *sql.DB
 }
 
 type GoalServiceInterface interface {
 	GetAllByUserId(name string, userID int64, f filters.Filters, v *validator.Validator) ([]*model.Goal, filters.Metadata, error)
 	GetById(v *validator.Validator, id, userID int64) (*model.Goal, error)
+	GetAccessible(v *validator.Validator, id, userID int64) (*model.Goal, error)
+	GetShared(userID int64) ([]*model.Goal, error)
 	Create(v *validator.Validator, goal *model.Goal) error
 	Update(v *validator.Validator, goal *model.Goal, userID int64) error
 	Delete(id, userID int64) error
+	Simulate(v *validator.Validator, id, userID int64, annualRate *float64, monthly *money.Money, months int) (*model.GoalSimulation, error)
+	ChangeStatus(v *validator.Validator, id, userID int64, action model.GoalAction, deadline *time.Time, reason string) (*model.Goal, error)
+	GetStatusHistory(id, userID int64) ([]*model.GoalStatusChange, error)
 }
 
-func NewGoalService(g repository.GoalRepositoryInterface) *GoalService {
+func NewGoalService(g repository.GoalRepositoryInterface, rates repository.InterestRateRepositoryInterface, db *sql.DB) *GoalService {
 	return &GoalService{
-		Goal: g,
+		Goal:  g,
+		rates: rates,
+		db:    db,
 	}
 }
 
@@ -42,11 +57,18 @@ func (s *GoalService) GetAllByUserId(
 		return nil, filters.Metadata{}, err
 	}
 
+	now := time.Now()
+	totals, err := s.Goal.GetContributionTotals(userID, forecastWindowStart(now))
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
 	for _, g := range goals {
 		if s.verifyFailedStatus(g) {
 			g.Status = model.GoalStatusFailed
 		}
 		s.calculateInstallments(g)
+		g.Forecast = goalForecast(g, totals[g.ID], now)
 	}
 
 	return goals, meta, nil
@@ -55,15 +77,84 @@ func (s *GoalService) GetAllByUserId(
 func (s *GoalService) GetById(v *validator.Validator, id, userID int64) (*model.Goal, error) {
 	goal, err := s.Goal.GetById(id, userID)
 	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			return nil, s.notOwned(id, userID)
+		}
 		return nil, err
 	}
 
-	s.updateStatus(v, userID, goal)
+	return s.prepare(v, goal)
+}
+
+func (s *GoalService) GetAccessible(v *validator.Validator, id, userID int64) (*model.Goal, error) {
+	goal, err := s.Goal.GetAccessible(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.User.ID != userID {
+		goal.User.Phone = ""
+	}
+
+	return s.prepare(v, goal)
+}
+
+func (s *GoalService) GetShared(userID int64) ([]*model.Goal, error) {
+	goals, err := s.Goal.GetShared(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	totalsByOwner := make(map[int64]map[int64]*model.ContributionTotal)
+
+	for _, g := range goals {
+		ownerID := g.User.ID
+		totals, ok := totalsByOwner[ownerID]
+		if !ok {
+			totals, err = s.Goal.GetContributionTotals(ownerID, forecastWindowStart(now))
+			if err != nil {
+				return nil, err
+			}
+			totalsByOwner[ownerID] = totals
+		}
+
+		if s.verifyFailedStatus(g) {
+			g.Status = model.GoalStatusFailed
+		}
+		s.calculateInstallments(g)
+		g.Forecast = goalForecast(g, totals[g.ID], now)
+		g.User.Phone = ""
+	}
+
+	return goals, nil
+}
+
+func (s *GoalService) prepare(v *validator.Validator, goal *model.Goal) (*model.Goal, error) {
+	ownerID := goal.User.ID
+
+	s.updateStatus(v, ownerID, goal)
 	s.calculateInstallments(goal)
+
+	now := time.Now()
+	totals, err := s.Goal.GetContributionTotals(ownerID, forecastWindowStart(now))
+	if err != nil {
+		return nil, err
+	}
+
+	goal.Forecast = goalForecast(goal, totals[goal.ID], now)
 	return goal, nil
 }
 
+func (s *GoalService) notOwned(id, userID int64) error {
+	if _, err := s.Goal.GetAccessible(id, userID); err == nil {
+		return e.ErrNotPermitted
+	}
+	return e.ErrRecordNotFound
+}
+
 func (s *GoalService) Create(v *validator.Validator, goal *model.Goal) error {
+	goal.Status = model.GoalStatusPending
 	if goal.ValidateGoal(v); !v.Valid() {
 		return e.ErrInvalidData
 	}
@@ -80,16 +171,89 @@ func (s *GoalService) Update(v *validator.Validator, goal *model.Goal, userID in
 		return e.ErrInvalidData
 	}
 
-	err := s.Goal.Update(goal, userID)
+	previous, err := s.Goal.GetById(goal.ID, userID)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		if err := s.Goal.Update(goal, userID, tx); err != nil {
+			return err
+		}
+
+		if previous.Status != goal.Status {
+			return s.Goal.InsertStatusChange(&model.GoalStatusChange{
+				GoalID: goal.ID,
+				From:   previous.Status,
+				To:     goal.Status,
+				Action: model.GoalActionAutomatic,
+			}, tx)
+		}
+		return nil
+	})
+}
+
+func (s *GoalService) ChangeStatus(
+	v *validator.Validator,
+	id, userID int64,
+	action model.GoalAction,
+	deadline *time.Time,
+	reason string,
+) (*model.Goal, error) {
+	goal, err := s.GetById(v, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStatus := goal.Status
+	previousDeadline := goal.Deadline
+
+	if goal.ApplyAction(v, action, deadline, time.Now()); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	change := &model.GoalStatusChange{
+		GoalID:    goal.ID,
+		From:      previousStatus,
+		To:        goal.Status,
+		Action:    action,
+		ChangedBy: &userID,
+		Reason:    reason,
+	}
+	if !goal.Deadline.Equal(previousDeadline) {
+		change.PreviousDeadline = &previousDeadline
+		change.NewDeadline = &goal.Deadline
+	}
+
+	err = utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		if err := s.Goal.Update(goal, userID, tx); err != nil {
+			return err
+		}
+		return s.Goal.InsertStatusChange(change, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	goal.Installments = nil
+	s.calculateInstallments(goal)
+	return goal, nil
+}
+
+func (s *GoalService) GetStatusHistory(id, userID int64) ([]*model.GoalStatusChange, error) {
+	if _, err := s.Goal.GetById(id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.Goal.GetStatusHistory(id, userID)
 }
 
 func (s *GoalService) Delete(id, userID int64) error {
 	err := s.Goal.Delete(id, userID)
 	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			return s.notOwned(id, userID)
+		}
 		return err
 	}
 	return nil
@@ -104,17 +268,26 @@ func (s *GoalService) updateStatus(v *validator.Validator, userID int64, goal *m
 }
 
 func (s *GoalService) verifyFailedStatus(goal *model.Goal) bool {
-	return time.Now().After(goal.Deadline) && goal.Current < goal.Amount && goal.Status != model.GoalStatusFailed
+	return time.Now().After(goal.Deadline) && goal.Current < goal.Amount && goal.IsOpen()
 }
 
 func (s *GoalService) calculateInstallments(goal *model.Goal) {
-	now := time.Now()
-	if goal.Deadline.Before(now) {
+	installments := goalInstallments(goal, time.Now())
+	if installments == nil {
 		return
 	}
 
-	if goal.Installments == nil {
-		goal.Installments = &model.Installments{}
+	goal.Installments = installments
+}
+
+func goalInstallments(goal *model.Goal, now time.Time) *model.Installments {
+	if goal.Deadline.Before(now) {
+		return nil
+	}
+
+	installments := &model.Installments{}
+	if goal.Installments != nil {
+		installments = goal.Installments
 	}
 
 	yearDiff := goal.Deadline.Year() - now.Year()
@@ -122,14 +295,210 @@ func (s *GoalService) calculateInstallments(goal *model.Goal) {
 	quantity := yearDiff*12 + monthDiff
 
 	if quantity <= 0 {
-		return
+		return installments
 	}
 
-	goal.Installments.Quantity = quantity
-	remaining := goal.Amount - goal.Current
+	installments.Quantity = quantity
+	remaining := goal.Amount.Sub(goal.Current)
 	if remaining <= 0 {
-		return
+		return installments
+	}
+
+	installments.Amount = requiredContribution(goal.Current, goal.Amount, quantity, goalMonthlyRate(goal))
+	return installments
+}
+
+func goalMonthlyRate(goal *model.Goal) float64 {
+	if goal.AnnualRate == nil {
+		return 0
+	}
+	return monthlyRate(*goal.AnnualRate)
+}
+
+func requiredContribution(current, target money.Money, months int, rate float64) money.Money {
+	if months <= 0 {
+		return money.Zero
+	}
+
+	if rate == 0 {
+		remaining := target.Sub(current)
+		if !remaining.IsPositive() {
+			return money.Zero
+		}
+		return remaining.Div(int64(months))
+	}
+
+	growth := math.Pow(1+rate, float64(months))
+	payment := (target.Float64() - current.Float64()*growth) * rate / (growth - 1)
+	if payment <= 0 {
+		return money.Zero
+	}
+
+	return money.FromCents(int64(math.Ceil(payment * 100)))
+}
+
+const (
+	forecastWindowMonths = 6
+	forecastAtRiskPace   = 0.75
+	daysPerMonth         = 30.4375
+)
+
+func forecastWindowStart(now time.Time) time.Time {
+	return now.AddDate(0, -forecastWindowMonths, 0)
+}
+
+func goalForecast(goal *model.Goal, total *model.ContributionTotal, now time.Time) *model.GoalForecast {
+	forecast := &model.GoalForecast{}
+
+	remaining := goal.Amount.Sub(goal.Current)
+	if !remaining.IsPositive() {
+		forecast.Status = model.GoalForecastCompleted
+		forecast.Pace = 1
+		return forecast
+	}
+
+	if total != nil {
+		start := forecastWindowStart(now)
+		if goal.CreatedAt.After(start) {
+			start = goal.CreatedAt
+		}
+		if total.First.Before(start) {
+			start = total.First
+		}
+
+		elapsed := math.Max(monthsBetween(start, now), 1)
+		forecast.MonthlyRate = total.Amount.DivRate(elapsed)
+	}
+
+	monthsLeft := monthsBetween(now, goal.Deadline)
+	if monthsLeft <= 0 {
+		forecast.RequiredMonthly = remaining
+	} else {
+		forecast.RequiredMonthly = remaining.DivRate(math.Max(monthsLeft, 1))
+	}
+
+	if forecast.MonthlyRate.IsPositive() {
+		months := remaining.Float64() / forecast.MonthlyRate.Float64()
+		projected := now.AddDate(0, 0, int(math.Ceil(months*daysPerMonth)))
+		date := projected.Format(model.DateLayout)
+		forecast.ProjectedCompletion = &date
+		forecast.Pace = math.Round(forecast.MonthlyRate.Float64()/forecast.RequiredMonthly.Float64()*100) / 100
+	}
+
+	switch {
+	case monthsLeft <= 0:
+		forecast.Status = model.GoalForecastOffTrack
+	case forecast.Pace >= 1:
+		forecast.Status = model.GoalForecastOnTrack
+	case forecast.Pace >= forecastAtRiskPace:
+		forecast.Status = model.GoalForecastAtRisk
+	default:
+		forecast.Status = model.GoalForecastOffTrack
+	}
+
+	return forecast
+}
+
+func monthsBetween(start, end time.Time) float64 {
+	return end.Sub(start).Hours() / 24 / daysPerMonth
+}
+
+const (
+	maxSimulationMonths = 600
+	rateHistoryMonths   = 12
+)
+
+func (s *GoalService) Simulate(
+	v *validator.Validator,
+	id, userID int64,
+	annualRate *float64,
+	monthly *money.Money,
+	months int,
+) (*model.GoalSimulation, error) {
+	goal, err := s.GetById(v, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if months == 0 {
+		months = (goal.Deadline.Year()-now.Year())*12 + int(goal.Deadline.Month()) - int(now.Month())
+		v.Check(months > 0, "months", "must be provided for a goal past its deadline")
+	} else {
+		v.Check(months > 0 && months <= maxSimulationMonths, "months", fmt.Sprintf("must be between 1 and %d", maxSimulationMonths))
+	}
+	if annualRate != nil {
+		v.Check(*annualRate >= 0 && *annualRate <= 100, "annual_rate", "must be between 0 and 100")
+	}
+	if monthly != nil {
+		v.Check(!monthly.IsNegative(), "monthly_contribution", "must not be negative")
+	}
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	simulation := &model.GoalSimulation{
+		GoalID:     goal.ID,
+		Currency:   goal.Currency,
+		Target:     goal.Amount,
+		RateSource: model.SimulationRateNone,
+		Months:     months,
+		Schedule:   make([]model.GoalSimulationMonth, 0, months),
+	}
+
+	switch {
+	case annualRate != nil:
+		simulation.AnnualRate = *annualRate
+		simulation.RateSource = model.SimulationRateQuery
+	case goal.AnnualRate != nil:
+		simulation.AnnualRate = *goal.AnnualRate
+		simulation.RateSource = model.SimulationRateGoal
+	default:
+		history, err := s.rates.GetAll(userID, rateHistoryMonths)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(history) > 0 {
+			total := 0.0
+			for _, rate := range history {
+				total += rate.AnnualRate
+			}
+			simulation.AnnualRate = math.Round(total/float64(len(history))*10000) / 10000
+			simulation.RateSource = model.SimulationRateHistory
+		}
+	}
+
+	rate := monthlyRate(simulation.AnnualRate)
+	simulation.RequiredMonthly = requiredContribution(goal.Current, goal.Amount, months, rate)
+
+	simulation.MonthlyContribution = simulation.RequiredMonthly
+	if monthly != nil {
+		simulation.MonthlyContribution = *monthly
+	}
+
+	balance := goal.Current
+	for i := 1; i <= months; i++ {
+		month := time.Date(now.Year(), now.Month()+time.Month(i), 1, 0, 0, 0, 0, time.UTC).Format(model.StatementMonthLayout)
+
+		point := model.GoalSimulationMonth{
+			Month:        month,
+			Opening:      balance,
+			Contribution: simulation.MonthlyContribution,
+			Interest:     balance.MulRate(rate),
+		}
+		point.Closing = point.Opening.Add(point.Interest).Add(point.Contribution)
+		balance = point.Closing
+
+		simulation.TotalContributions = simulation.TotalContributions.Add(point.Contribution)
+		simulation.TotalInterest = simulation.TotalInterest.Add(point.Interest)
+		if simulation.ReachedIn == nil && balance >= goal.Amount {
+			simulation.ReachedIn = &month
+		}
+
+		simulation.Schedule = append(simulation.Schedule, point)
 	}
 
-	goal.Installments.Amount = remaining / float64(quantity)
+	simulation.FinalBalance = balance
+	return simulation, nil
 }
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+)
+
+type GoalMemberService struct {
+	member repository.GoalMemberRepositoryInterface
+	goal   GoalServiceInterface
+	user   repository.UserRepository
+}
+
+type GoalMemberServiceInterface interface {
+	GetByGoalID(v *validator.Validator, goalID, userID int64) ([]*model.GoalMember, error)
+	GetInvites(userID int64) ([]*model.GoalMember, error)
+	Invite(v *validator.Validator, invite *model.GoalInviteDTO, userID int64) (*model.GoalMember, error)
+	Respond(v *validator.Validator, id, userID int64, accept bool) (*model.GoalMember, error)
+	Remove(id, userID int64) error
+	GetContributors(v *validator.Validator, goalID, userID int64) ([]*model.GoalContributor, error)
+}
+
+func NewGoalMemberService(
+	member repository.GoalMemberRepositoryInterface,
+	goal GoalServiceInterface,
+	user repository.UserRepository,
+) *GoalMemberService {
+	return &GoalMemberService{
+		member: member,
+		goal:   goal,
+		user:   user,
+	}
+}
+
+func (s *GoalMemberService) GetByGoalID(v *validator.Validator, goalID, userID int64) ([]*model.GoalMember, error) {
+	if _, err := s.goal.GetAccessible(v, goalID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.member.GetByGoalID(goalID)
+}
+
+func (s *GoalMemberService) GetInvites(userID int64) ([]*model.GoalMember, error) {
+	return s.member.GetInvites(userID)
+}
+
+func (s *GoalMemberService) Invite(v *validator.Validator, invite *model.GoalInviteDTO, userID int64) (*model.GoalMember, error) {
+	if invite.Validate(v); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	goal, err := s.goal.GetById(v, *invite.GoalID, userID)
+	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("goal_id", "must reference an existing goal")
+			return nil, e.ErrInvalidData
+		}
+		return nil, err
+	}
+
+	invitee, err := s.user.GetByEmail(*invite.Email)
+	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("email", "must belong to a registered user")
+			return nil, e.ErrInvalidData
+		}
+		return nil, err
+	}
+
+	if v.Check(invitee.ID != userID, "email", "must not belong to the goal owner"); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	member := &model.GoalMember{
+		Goal:      goal,
+		User:      invitee,
+		InvitedBy: userID,
+		Status:    model.GoalMemberPending,
+	}
+
+	if err := s.member.Insert(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *GoalMemberService) Respond(v *validator.Validator, id, userID int64, accept bool) (*model.GoalMember, error) {
+	member, err := s.member.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.User.ID != userID {
+		return nil, e.ErrRecordNotFound
+	}
+
+	if v.Check(member.Status == model.GoalMemberPending, "status", "invite has already been answered"); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	member.Status = model.GoalMemberDeclined
+	if accept {
+		member.Status = model.GoalMemberAccepted
+	}
+
+	if err := s.member.UpdateStatus(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *GoalMemberService) Remove(id, userID int64) error {
+	member, err := s.member.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if member.User.ID != userID && member.Goal.User.ID != userID {
+		return e.ErrRecordNotFound
+	}
+
+	return s.member.Delete(id)
+}
+
+func (s *GoalMemberService) GetContributors(v *validator.Validator, goalID, userID int64) ([]*model.GoalContributor, error) {
+	if _, err := s.goal.GetAccessible(v, goalID, userID); err != nil {
+		return nil, err
+	}
+
+	contributors, err := s.member.GetContributors(goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := money.Zero
+	for _, contributor := range contributors {
+		total = total.Add(contributor.Total)
+	}
+
+	if total.IsPositive() {
+		for _, contributor := range contributors {
+			contributor.Percentage = money.Percent(contributor.Total, total)
+		}
+	}
+
+	return contributors, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/repository"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"time"
+)
+
+type GoalMilestoneService struct {
+	milestone repository.GoalMilestoneRepositoryInterface
+	goal      GoalServiceInterface
+	db        *sql.DB
+}
+
+type GoalMilestoneServiceInterface interface {
+	GetByGoalID(goalID, userID int64) ([]*model.GoalMilestone, error)
+	Insert(v *validator.Validator, milestone *model.GoalMilestone, userID int64) error
+	Update(v *validator.Validator, milestone *model.GoalMilestone, userID int64) error
+	Delete(id, userID int64) error
+	GetTimeline(goalID, userID int64) ([]*model.GoalEvent, error)
+	Evaluate(goal *model.Goal, userID int64) error
+}
+
+func NewGoalMilestoneService(
+	milestone repository.GoalMilestoneRepositoryInterface,
+	goal GoalServiceInterface,
+	db *sql.DB,
+) *GoalMilestoneService {
+	return &GoalMilestoneService{
+		milestone: milestone,
+		goal:      goal,
+		db:        db,
+	}
+}
+
+func (s *GoalMilestoneService) GetByGoalID(goalID, userID int64) ([]*model.GoalMilestone, error) {
+	return s.milestone.GetByGoalID(goalID, userID)
+}
+
+func (s *GoalMilestoneService) Insert(v *validator.Validator, milestone *model.GoalMilestone, userID int64) error {
+	if milestone.ValidateGoalMilestone(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	goal, err := s.goal.GetById(v, milestone.Goal.ID, userID)
+	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("goal_id", "must reference an existing goal")
+			return e.ErrInvalidData
+		}
+		return err
+	}
+
+	if milestone.Kind == model.MilestoneAmount {
+		if v.Check(milestone.Amount <= goal.Amount, "amount", "must not exceed the goal amount"); !v.Valid() {
+			return e.ErrInvalidData
+		}
+	}
+
+	milestone.Goal = goal
+	if err := s.milestone.Insert(milestone); err != nil {
+		return err
+	}
+
+	return s.evaluate(goal, []*model.GoalMilestone{milestone})
+}
+
+func (s *GoalMilestoneService) Update(v *validator.Validator, milestone *model.GoalMilestone, userID int64) error {
+	current, err := s.milestone.GetByID(milestone.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	milestone.Goal = current.Goal
+	milestone.ReachedAt = current.ReachedAt
+	if milestone.ValidateGoalMilestone(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	goal, err := s.goal.GetById(v, current.Goal.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	if milestone.Kind == model.MilestoneAmount {
+		if v.Check(milestone.Amount <= goal.Amount, "amount", "must not exceed the goal amount"); !v.Valid() {
+			return e.ErrInvalidData
+		}
+	}
+
+	milestone.Goal = goal
+	if err := s.milestone.Update(milestone, userID); err != nil {
+		return err
+	}
+
+	return s.evaluate(goal, []*model.GoalMilestone{milestone})
+}
+
+func (s *GoalMilestoneService) Delete(id, userID int64) error {
+	return s.milestone.Delete(id, userID)
+}
+
+func (s *GoalMilestoneService) GetTimeline(goalID, userID int64) ([]*model.GoalEvent, error) {
+	if _, err := s.goal.GetById(validator.New(), goalID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.milestone.GetEvents(goalID, userID)
+}
+
+func (s *GoalMilestoneService) Evaluate(goal *model.Goal, userID int64) error {
+	milestones, err := s.milestone.GetByGoalID(goal.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.evaluate(goal, milestones)
+}
+
+func (s *GoalMilestoneService) evaluate(goal *model.Goal, milestones []*model.GoalMilestone) error {
+	for _, milestone := range milestones {
+		milestone.Goal = goal
+		reached := goal.Current >= milestone.Target()
+
+		event := &model.GoalEvent{
+			GoalID:      goal.ID,
+			MilestoneID: &milestone.ID,
+			Amount:      goal.Current,
+		}
+
+		switch {
+		case reached && milestone.ReachedAt == nil:
+			now := time.Now().UTC()
+			milestone.ReachedAt = &now
+			event.Type = model.GoalEventMilestoneReached
+			event.Description = fmt.Sprintf("Milestone %q reached", milestone.Name)
+		case !reached && milestone.ReachedAt != nil:
+			milestone.ReachedAt = nil
+			event.Type = model.GoalEventMilestoneLost
+			event.Description = fmt.Sprintf("Milestone %q is no longer reached", milestone.Name)
+		default:
+			continue
+		}
+
+		err := utils.RunInTx(s.db, func(tx *sql.Tx) error {
+			if err := s.milestone.UpdateReached(milestone, tx); err != nil {
+				return err
+			}
+			return s.milestone.InsertEvent(event, tx)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
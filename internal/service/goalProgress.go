@@ -1,27 +1,60 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
 	"financas/internal/repository"
+	"financas/utils"
 	e "financas/utils/errors"
 	"financas/utils/validator"
+	"fmt"
 	"time"
 )
 
 type GoalProgressService struct {
-	gP   repository.GoalProgressRepositoryInterface
-	goal GoalServiceInterface
+	gP          repository.GoalProgressRepositoryInterface
+	goal        GoalServiceInterface
+	goals       repository.GoalRepositoryInterface
+	transaction repository.TransactionRepositoryInterface
+	category    repository.CategoryRepositoryIntercafe
+	milestone   GoalMilestoneServiceInterface
+	db          *sql.DB
 }
 
 type GoalProgressServiceInterface interface {
 	GetGoalProgressIDGoal(userID, goalID int64) ([]*model.GoalProgress, error)
+	GetAllByGoalID(v *validator.Validator, userID, goalID int64, filter model.GoalProgressFilter, f filters.Filters) ([]*model.GoalProgress, filters.Metadata, error)
+	GetMonthlySummary(v *validator.Validator, userID, goalID int64, startDate, endDate *time.Time) ([]*model.GoalProgressMonth, error)
 	Insert(v *validator.Validator, gP *model.GoalProgress, userID int64) error
+	InsertWith(v *validator.Validator, gP *model.GoalProgress, userID int64, fn func(tx *sql.Tx) error) error
+	Withdraw(v *validator.Validator, goalID, userID int64, amount money.Money, reason string) (*model.GoalProgress, error)
 	Update(v *validator.Validator, gP *model.GoalProgress, userID int64) error
 	Delete(v *validator.Validator, goalProgressID, userID int64) error
+	SyncTransaction(v *validator.Validator, t *model.Transaction, userID int64, fn func(tx *sql.Tx) error) error
+	RemoveTransaction(v *validator.Validator, transactionID, userID int64, fn func(tx *sql.Tx) error) error
 }
 
-func NewGoalProgressService(gP repository.GoalProgressRepositoryInterface, goal GoalServiceInterface) *GoalProgressService {
-	return &GoalProgressService{gP: gP, goal: goal}
+func NewGoalProgressService(
+	gP repository.GoalProgressRepositoryInterface,
+	goal GoalServiceInterface,
+	goals repository.GoalRepositoryInterface,
+	transaction repository.TransactionRepositoryInterface,
+	category repository.CategoryRepositoryIntercafe,
+	milestone GoalMilestoneServiceInterface,
+	db *sql.DB,
+) *GoalProgressService {
+	return &GoalProgressService{
+		gP:          gP,
+		goal:        goal,
+		goals:       goals,
+		transaction: transaction,
+		category:    category,
+		milestone:   milestone,
+		db:          db,
+	}
 }
 
 func (s *GoalProgressService) GetGoalProgressIDGoal(userID, goalID int64) ([]*model.GoalProgress, error) {
@@ -32,6 +65,43 @@ func (s *GoalProgressService) GetGoalProgressIDGoal(userID, goalID int64) ([]*mo
 	return gPs, nil
 }
 
+func (s *GoalProgressService) GetAllByGoalID(
+	v *validator.Validator,
+	userID, goalID int64,
+	filter model.GoalProgressFilter,
+	f filters.Filters,
+) ([]*model.GoalProgress, filters.Metadata, error) {
+	filters.ValidateFilters(v, f)
+	if filter.Validate(v); !v.Valid() {
+		return nil, filters.Metadata{}, e.ErrInvalidData
+	}
+
+	goal, err := s.goal.GetAccessible(v, goalID, userID)
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	return s.gP.GetAllByGoalID(goal.User.ID, goalID, filter, f)
+}
+
+func (s *GoalProgressService) GetMonthlySummary(
+	v *validator.Validator,
+	userID, goalID int64,
+	startDate, endDate *time.Time,
+) ([]*model.GoalProgressMonth, error) {
+	filter := model.GoalProgressFilter{StartDate: startDate, EndDate: endDate}
+	if filter.Validate(v); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	goal, err := s.goal.GetAccessible(v, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gP.GetMonthlySummary(goal.User.ID, goalID, startDate, endDate)
+}
+
 func (s *GoalProgressService) GetGoalProgressByID(userID, gPID int64) (*model.GoalProgress, error) {
 	gP, err := s.gP.GetGoalProgressByID(userID, gPID)
 	if err != nil {
@@ -41,96 +111,326 @@ func (s *GoalProgressService) GetGoalProgressByID(userID, gPID int64) (*model.Go
 }
 
 func (s *GoalProgressService) Insert(v *validator.Validator, gP *model.GoalProgress, userID int64) error {
-	if gP.ValidateGoalProgress(v); !v.Valid() {
+	return s.InsertWith(v, gP, userID, nil)
+}
+
+func (s *GoalProgressService) InsertWith(
+	v *validator.Validator,
+	gP *model.GoalProgress,
+	userID int64,
+	fn func(tx *sql.Tx) error,
+) error {
+	if gP.Goal == nil {
+		v.AddError("goal", "must be provided")
 		return e.ErrInvalidData
 	}
 
-	gP.Date = time.Now()
-
-	err := s.gP.Insert(gP)
+	goal, err := s.goal.GetAccessible(v, gP.Goal.ID, userID)
 	if err != nil {
 		return err
 	}
+	if v.Check(goal.Status != model.GoalStatusArchived, "goal", "must not be archived"); !v.Valid() {
+		return e.ErrInvalidData
+	}
 
-	goal, err := s.goal.GetById(v, gP.Goal.ID, userID)
+	gP.Goal = goal
+	gP.User = &model.User{ID: userID}
+	gP.Date = time.Now()
 
-	if err != nil {
-		return err
+	if gP.Transaction != nil && !gP.TransactionCreated {
+		if err := s.linkTransaction(v, gP, userID); err != nil {
+			return err
+		}
+	}
+
+	if gP.Kind == model.GoalProgressWithdrawal {
+		v.Check(gP.Reason != "", "reason", "must be provided for a withdrawal")
+	}
+	if gP.ValidateGoalProgress(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	if gP.TransactionCreated {
+		if err := s.buildTransaction(v, gP, userID); err != nil {
+			return err
+		}
 	}
 
-	goal.Current += gP.Amount
+	return s.applyToGoal(v, goal.ID, func(tx *sql.Tx) error {
+		if gP.TransactionCreated {
+			if err := s.transaction.Insert(gP.Transaction, tx); err != nil {
+				return err
+			}
+		}
+		if err := s.gP.Insert(gP, tx); err != nil {
+			return err
+		}
 
-	if goal.Current >= goal.Amount {
-		goal.Status = model.GoalStatusFinished
+		if fn != nil {
+			return fn(tx)
+		}
+		return nil
+	})
+}
+
+func (s *GoalProgressService) Withdraw(
+	v *validator.Validator,
+	goalID, userID int64,
+	amount money.Money,
+	reason string,
+) (*model.GoalProgress, error) {
+	if v.Check(amount.IsPositive(), "amount", "must be greater than zero"); !v.Valid() {
+		return nil, e.ErrInvalidData
 	}
 
-	err = s.goal.Update(v, goal, userID)
-	if err != nil {
-		return err
+	gP := &model.GoalProgress{
+		Goal:   &model.Goal{ID: goalID},
+		Kind:   model.GoalProgressWithdrawal,
+		Amount: amount.Neg(),
+		Reason: reason,
 	}
 
-	return nil
+	if err := s.Insert(v, gP, userID); err != nil {
+		return nil, err
+	}
+
+	return gP, nil
 }
 
 func (s *GoalProgressService) Update(v *validator.Validator, gP *model.GoalProgress, userID int64) error {
+	current, err := s.gP.GetGoalProgressByID(userID, gP.ID)
+	if err != nil {
+		return err
+	}
+
+	if current.Transaction != nil && current.User.ID != userID {
+		return e.ErrNotPermitted
+	}
+
+	gP.Goal = current.Goal
+	gP.User = current.User
+	gP.Kind = current.Kind
+	gP.Transaction = current.Transaction
+	gP.TransactionCreated = current.TransactionCreated
+	if gP.Date.IsZero() {
+		gP.Date = current.Date
+	}
+	if gP.Reason == "" {
+		gP.Reason = current.Reason
+	}
+
 	if gP.ValidateGoalProgress(v); !v.Valid() {
 		return e.ErrInvalidData
 	}
 
-	err := s.gP.Update(gP, userID)
-	if err != nil {
-		return err
+	var transaction *model.Transaction
+	if gP.Transaction != nil && gP.Amount != current.Amount {
+		if v.Check(gP.Amount.IsPositive(), "amount", "must be positive for a contribution linked to a transaction"); !v.Valid() {
+			return e.ErrInvalidData
+		}
+
+		transaction, err = s.transaction.GetByID(gP.Transaction.ID, userID)
+		if err != nil {
+			return err
+		}
+		transaction.Amount = gP.Amount
 	}
 
-	return s.updateGoalStatus(v, gP.ID, userID)
+	return s.applyToGoal(v, gP.Goal.ID, func(tx *sql.Tx) error {
+		if transaction != nil {
+			if err := s.transaction.Update(transaction, tx); err != nil {
+				return err
+			}
+		}
+		return s.gP.Update(gP, userID, tx)
+	})
 }
 
 func (s *GoalProgressService) Delete(v *validator.Validator, goalProgressID, userID int64) error {
-	err := s.gP.Delete(goalProgressID, userID)
+	gP, err := s.gP.GetGoalProgressByID(userID, goalProgressID)
 	if err != nil {
 		return err
 	}
-	return s.updateGoalStatus(v, goalProgressID, userID)
+
+	if gP.Transaction != nil && gP.User.ID != userID {
+		return e.ErrNotPermitted
+	}
+
+	return s.applyToGoal(v, gP.Goal.ID, func(tx *sql.Tx) error {
+		if err := s.gP.Delete(goalProgressID, userID, tx); err != nil {
+			return err
+		}
+
+		if gP.TransactionCreated && gP.Transaction != nil {
+			err := s.transaction.Delete(gP.Transaction.ID, userID, tx)
+			if err != nil && !errors.Is(err, e.ErrRecordNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (s *GoalProgressService) updateGoalStatus(v *validator.Validator, gPID, userID int64) error {
-	gP, err := s.GetGoalProgressByID(userID, gPID)
+func (s *GoalProgressService) SyncTransaction(
+	v *validator.Validator,
+	t *model.Transaction,
+	userID int64,
+	fn func(tx *sql.Tx) error,
+) error {
+	gP, err := s.linkedProgress(t.ID, userID)
 	if err != nil {
 		return err
 	}
+	if gP == nil {
+		return utils.RunInTx(s.db, fn)
+	}
 
-	gPs, err := s.gP.GetGoalProgressIDGoal(userID, gP.Goal.ID)
+	category, err := s.category.GetByID(t.Category.ID, userID)
 	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("category_id", "must reference an existing category")
+			return e.ErrInvalidData
+		}
 		return err
 	}
 
-	var totalAmount float64 = 0
-	for _, gp := range gPs {
-		totalAmount += gp.Amount
+	v.Check(category.Type == model.DESPESA, "category_id", "must reference an expense category while linked to a goal contribution")
+	v.Check(t.Currency == gP.Goal.Currency, "currency", "must match the goal currency while linked to a goal contribution")
+	if !v.Valid() {
+		return e.ErrInvalidData
 	}
 
-	goal, err := s.goal.GetById(v, gP.Goal.ID, userID)
+	gP.Amount = t.Amount
+	return s.applyToGoal(v, gP.Goal.ID, func(tx *sql.Tx) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return s.gP.Update(gP, userID, tx)
+	})
+}
 
+func (s *GoalProgressService) RemoveTransaction(
+	v *validator.Validator,
+	transactionID, userID int64,
+	fn func(tx *sql.Tx) error,
+) error {
+	gP, err := s.linkedProgress(transactionID, userID)
 	if err != nil {
 		return err
 	}
+	if gP == nil {
+		return utils.RunInTx(s.db, fn)
+	}
 
-	goal.Current = totalAmount
+	return s.applyToGoal(v, gP.Goal.ID, func(tx *sql.Tx) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return s.gP.Delete(gP.ID, userID, tx)
+	})
+}
 
-	if goal.Status != model.GoalStatusFinished {
-		if time.Now().After(goal.Deadline) && goal.Current < goal.Amount {
-			goal.Status = model.GoalStatusFailed
-		} else if goal.Current >= goal.Amount && goal.Status != model.GoalStatusFailed {
-			goal.Status = model.GoalStatusFinished
-		} else if goal.Current > 0 {
-			goal.Status = model.GoalStatusInProgress
+func (s *GoalProgressService) linkTransaction(v *validator.Validator, gP *model.GoalProgress, userID int64) error {
+	transaction, err := s.transaction.GetByID(gP.Transaction.ID, userID)
+	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("transaction_id", "must reference an existing transaction")
+			return e.ErrInvalidData
 		}
+		return err
+	}
+
+	v.Check(transaction.Category.Type == model.DESPESA, "transaction_id", "must reference an expense transaction")
+	v.Check(transaction.Currency == gP.Goal.Currency, "transaction_id", "must be in the same currency as the goal")
+	if !v.Valid() {
+		return e.ErrInvalidData
 	}
 
-	err = s.goal.Update(v, goal, userID)
+	gP.Transaction = transaction
+	gP.Amount = transaction.Amount
+	gP.Date = transaction.CreatedAt
+	return nil
+}
+
+func (s *GoalProgressService) linkedProgress(transactionID, userID int64) (*model.GoalProgress, error) {
+	gP, err := s.gP.GetByTransactionID(userID, transactionID)
 	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return gP, nil
+}
+
+func (s *GoalProgressService) buildTransaction(v *validator.Validator, gP *model.GoalProgress, userID int64) error {
+	category, err := s.category.GetByID(gP.Transaction.Category.ID, userID)
+	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("category_id", "must reference an existing category")
+			return e.ErrInvalidData
+		}
 		return err
 	}
 
+	if v.Check(category.Type == model.DESPESA, "category_id", "must reference an expense category"); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	gP.Transaction = &model.Transaction{
+		User:        &model.User{ID: userID},
+		Category:    category,
+		Description: fmt.Sprintf("Goal contribution: %s", gP.Goal.Name),
+		Amount:      gP.Amount,
+		Currency:    gP.Goal.Currency,
+	}
 	return nil
 }
+
+func (s *GoalProgressService) applyToGoal(v *validator.Validator, goalID int64, fn func(tx *sql.Tx) error) error {
+	var goal *model.Goal
+
+	err := utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		var err error
+		goal, err = s.goals.GetForUpdate(goalID, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		total, err := s.gP.GetTotal(goalID, tx)
+		if err != nil {
+			return err
+		}
+		if v.Check(!total.IsNegative(), "amount", "must not leave the goal balance below zero"); !v.Valid() {
+			return e.ErrInvalidData
+		}
+
+		previous := goal.Status
+		goal.Current = total
+		goal.RefreshStatus(time.Now())
+
+		if err := s.goals.Update(goal, goal.User.ID, tx); err != nil {
+			return err
+		}
+
+		if goal.Status != previous {
+			return s.goals.InsertStatusChange(&model.GoalStatusChange{
+				GoalID: goal.ID,
+				From:   previous,
+				To:     goal.Status,
+				Action: model.GoalActionAutomatic,
+			}, tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.milestone.Evaluate(goal, goal.User.ID)
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type GoalScheduleService struct {
+	schedule     repository.GoalScheduleRepositoryInterface
+	goal         GoalServiceInterface
+	goalProgress GoalProgressServiceInterface
+	db           *sql.DB
+}
+
+type GoalScheduleServiceInterface interface {
+	GetAll(userID int64) ([]*model.GoalSchedule, error)
+	GetByID(id, userID int64) (*model.GoalSchedule, error)
+	Insert(v *validator.Validator, schedule *model.GoalSchedule) error
+	Update(v *validator.Validator, schedule *model.GoalSchedule) error
+	Delete(id, userID int64) error
+	Pause(id, userID int64) (*model.GoalSchedule, error)
+	Resume(id, userID int64) (*model.GoalSchedule, error)
+	RunDue(now time.Time) (int, int, error)
+}
+
+func NewGoalScheduleService(
+	schedule repository.GoalScheduleRepositoryInterface,
+	goal GoalServiceInterface,
+	goalProgress GoalProgressServiceInterface,
+	db *sql.DB,
+) *GoalScheduleService {
+	return &GoalScheduleService{
+		schedule:     schedule,
+		goal:         goal,
+		goalProgress: goalProgress,
+		db:           db,
+	}
+}
+
+func (s *GoalScheduleService) GetAll(userID int64) ([]*model.GoalSchedule, error) {
+	return s.schedule.GetAll(userID)
+}
+
+func (s *GoalScheduleService) GetByID(id, userID int64) (*model.GoalSchedule, error) {
+	schedule, err := s.schedule.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Runs, err = s.schedule.GetRuns(schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *GoalScheduleService) Insert(v *validator.Validator, schedule *model.GoalSchedule) error {
+	if schedule.ValidateGoalSchedule(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	goal, err := s.goal.GetById(v, schedule.Goal.ID, schedule.User.ID)
+	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("goal_id", "must reference an existing goal")
+			return e.ErrInvalidData
+		}
+		return err
+	}
+
+	if v.Check(goal.IsOpen(), "goal_id", "must reference a goal that is still open"); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	if schedule.Mode != model.ContributionFixed {
+		schedule.Amount = money.Zero
+	}
+
+	schedule.Goal = goal
+	schedule.NextRun = model.NextScheduleRun(schedule.DayOfMonth, today())
+
+	return s.schedule.Insert(schedule)
+}
+
+func (s *GoalScheduleService) Update(v *validator.Validator, schedule *model.GoalSchedule) error {
+	current, err := s.schedule.GetByID(schedule.ID, schedule.User.ID)
+	if err != nil {
+		return err
+	}
+
+	schedule.Goal = current.Goal
+	if schedule.ValidateGoalSchedule(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	if schedule.Mode != model.ContributionFixed {
+		schedule.Amount = money.Zero
+	}
+
+	schedule.Paused = current.Paused
+	schedule.NextRun = current.NextRun
+	if schedule.DayOfMonth != current.DayOfMonth {
+		schedule.NextRun = model.NextScheduleRun(schedule.DayOfMonth, today())
+	}
+
+	return s.schedule.Update(schedule)
+}
+
+func (s *GoalScheduleService) Delete(id, userID int64) error {
+	return s.schedule.Delete(id, userID)
+}
+
+func (s *GoalScheduleService) Pause(id, userID int64) (*model.GoalSchedule, error) {
+	return s.setPaused(id, userID, true)
+}
+
+func (s *GoalScheduleService) Resume(id, userID int64) (*model.GoalSchedule, error) {
+	return s.setPaused(id, userID, false)
+}
+
+func (s *GoalScheduleService) RunDue(now time.Time) (int, int, error) {
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	schedules, err := s.schedule.GetDue(date)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	executed, skipped := 0, 0
+	errs := []error{}
+
+	for _, schedule := range schedules {
+		for !schedule.NextRun.After(date) {
+			run, err := s.run(schedule, date)
+			if errors.Is(err, e.ErrEditConflict) {
+				break
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("schedule %d: %w", schedule.ID, err))
+				break
+			}
+
+			if run.Status == model.ScheduleRunExecuted {
+				executed++
+			} else {
+				skipped++
+			}
+		}
+	}
+
+	return executed, skipped, errors.Join(errs...)
+}
+
+func (s *GoalScheduleService) setPaused(id, userID int64, paused bool) (*model.GoalSchedule, error) {
+	schedule, err := s.schedule.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Paused = paused
+	if !paused && schedule.NextRun.Before(today()) {
+		schedule.NextRun = model.NextScheduleRun(schedule.DayOfMonth, today())
+	}
+
+	if err := s.schedule.Update(schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *GoalScheduleService) run(schedule *model.GoalSchedule, date time.Time) (*model.GoalScheduleRun, error) {
+	run := &model.GoalScheduleRun{
+		ScheduleID: schedule.ID,
+		DueDate:    schedule.NextRun,
+		Status:     model.ScheduleRunSkipped,
+	}
+
+	userID := schedule.User.ID
+
+	switch {
+	case schedule.Paused:
+		run.Reason = "schedule is paused"
+	case !schedule.NextRun.AddDate(0, 1, 0).After(date):
+		run.Reason = "due date was missed"
+	default:
+		v := validator.New()
+
+		goal, err := s.goal.GetById(v, schedule.Goal.ID, userID)
+		if err != nil && !errors.Is(err, e.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		switch {
+		case goal == nil:
+			run.Reason = "goal no longer exists"
+		case !goal.IsOpen():
+			run.Reason = fmt.Sprintf("goal is %s", strings.ToLower(goal.Status.String()))
+		default:
+			amount := schedule.Amount
+			if schedule.Mode == model.ContributionInstallment {
+				amount = money.Zero
+				if goal.Installments != nil {
+					amount = goal.Installments.Amount
+				}
+			}
+
+			if !amount.IsPositive() {
+				run.Reason = "no installment left to contribute"
+				break
+			}
+
+			gP := &model.GoalProgress{
+				Goal:   &model.Goal{ID: goal.ID},
+				Kind:   model.GoalProgressContribution,
+				Amount: amount,
+			}
+
+			err := s.goalProgress.InsertWith(v, gP, userID, func(tx *sql.Tx) error {
+				run.Status = model.ScheduleRunExecuted
+				run.Amount = amount
+				run.GoalProgressID = &gP.ID
+				return s.complete(schedule, run, tx)
+			})
+			switch {
+			case errors.Is(err, e.ErrInvalidData):
+				run.Reason = validationReason(v)
+			case err != nil:
+				return nil, err
+			default:
+				return run, nil
+			}
+		}
+	}
+
+	err := utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		return s.complete(schedule, run, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (s *GoalScheduleService) complete(schedule *model.GoalSchedule, run *model.GoalScheduleRun, tx *sql.Tx) error {
+	if err := s.schedule.InsertRun(run, tx); err != nil {
+		return err
+	}
+
+	next := *schedule
+	next.NextRun = schedule.NextRun.AddDate(0, 1, 0)
+	if err := s.schedule.UpdateNextRun(&next, tx); err != nil {
+		return err
+	}
+
+	*schedule = next
+	return nil
+}
+
+func validationReason(v *validator.Validator) string {
+	reasons := make([]string, 0, len(v.Errors))
+	for key, message := range v.Errors {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", key, message))
+	}
+	sort.Strings(reasons)
+	return strings.Join(reasons, "; ")
+}
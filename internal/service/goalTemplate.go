@@ -0,0 +1,269 @@
+package service
+
+import (
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"time"
+)
+
+const (
+	templateHistoryMonths     = 6
+	templateDefaultCoverage   = 6
+	templateDefaultHorizon    = 12
+	thirteenthSalaryDeadline  = 20
+	thirteenthSalaryLeadMonth = 1
+)
+
+type goalTemplateInfo struct {
+	name        string
+	description string
+	color       string
+}
+
+var goalTemplates = map[model.GoalTemplateKind]goalTemplateInfo{
+	model.GoalTemplateEmergencyFund: {
+		name:        "Emergency fund",
+		description: "Reserve covering several months of your average expenses",
+		color:       "#e15759",
+	},
+	model.GoalTemplateVacation: {
+		name:        "Vacation",
+		description: "Save for a trip, sized by default as one month of your average spending",
+		color:       "#4e79a7",
+	},
+	model.GoalTemplateThirteenthSalary: {
+		name:        "13th salary reserve",
+		description: "Set aside one month of your average income by December 20th",
+		color:       "#59a14f",
+	},
+}
+
+type GoalTemplateService struct {
+	report repository.ReportRepositoryInterface
+	goal   GoalServiceInterface
+}
+
+type GoalTemplateServiceInterface interface {
+	GetCatalog(user *model.User) ([]*model.GoalTemplate, error)
+	Preview(v *validator.Validator, user *model.User, kind model.GoalTemplateKind, input *model.GoalTemplateInput) (*model.GoalTemplatePreview, error)
+	Instantiate(v *validator.Validator, user *model.User, kind model.GoalTemplateKind, input *model.GoalTemplateInput) (*model.Goal, error)
+}
+
+func NewGoalTemplateService(report repository.ReportRepositoryInterface, goal GoalServiceInterface) *GoalTemplateService {
+	return &GoalTemplateService{
+		report: report,
+		goal:   goal,
+	}
+}
+
+func (s *GoalTemplateService) GetCatalog(user *model.User) ([]*model.GoalTemplate, error) {
+	now := time.Now().UTC()
+	history, currency, err := s.history(user.ID, now)
+	if err != nil && !errors.Is(err, e.ErrExchangeRateNotFound) {
+		return nil, err
+	}
+
+	templates := make([]*model.GoalTemplate, 0, len(model.GoalTemplateKinds))
+	for _, kind := range model.GoalTemplateKinds {
+		info := goalTemplates[kind]
+		template := &model.GoalTemplate{
+			Kind:        kind.String(),
+			Name:        info.name,
+			Description: info.description,
+		}
+
+		if err != nil {
+			templates = append(templates, template)
+			continue
+		}
+
+		goal, covered := buildTemplateGoal(validator.New(), user, kind, &model.GoalTemplateInput{}, history, currency, now)
+		if goal != nil {
+			template.Preview = templatePreview(kind, goal, covered, history)
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (s *GoalTemplateService) Preview(
+	v *validator.Validator,
+	user *model.User,
+	kind model.GoalTemplateKind,
+	input *model.GoalTemplateInput,
+) (*model.GoalTemplatePreview, error) {
+	now := time.Now().UTC()
+	goal, covered, history, err := s.build(v, user, kind, input, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return templatePreview(kind, goal, covered, history), nil
+}
+
+func (s *GoalTemplateService) Instantiate(
+	v *validator.Validator,
+	user *model.User,
+	kind model.GoalTemplateKind,
+	input *model.GoalTemplateInput,
+) (*model.Goal, error) {
+	now := time.Now().UTC()
+	goal, _, _, err := s.build(v, user, kind, input, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.goal.Create(v, goal); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+func (s *GoalTemplateService) build(
+	v *validator.Validator,
+	user *model.User,
+	kind model.GoalTemplateKind,
+	input *model.GoalTemplateInput,
+	now time.Time,
+) (*model.Goal, int, model.GoalTemplateHistory, error) {
+	v.Check(kind != 0, "kind", "must be EMERGENCY_FUND, VACATION or THIRTEENTH_SALARY")
+	if input.Validate(v); !v.Valid() {
+		return nil, 0, model.GoalTemplateHistory{}, e.ErrInvalidData
+	}
+
+	history, currency, err := s.history(user.ID, now)
+	if err != nil {
+		return nil, 0, model.GoalTemplateHistory{}, err
+	}
+
+	goal, covered := buildTemplateGoal(v, user, kind, input, history, currency, now)
+	if !v.Valid() {
+		return nil, 0, model.GoalTemplateHistory{}, e.ErrInvalidData
+	}
+
+	return goal, covered, history, nil
+}
+
+func (s *GoalTemplateService) history(userID int64, now time.Time) (model.GoalTemplateHistory, string, error) {
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -templateHistoryMonths, 0)
+
+	transactions, currency, err := s.report.GetConvertedTransactions(userID, start, end)
+	if err != nil {
+		return model.GoalTemplateHistory{}, "", err
+	}
+
+	months := make(map[string]bool)
+	expenses, income := money.Zero, money.Zero
+
+	for _, t := range transactions {
+		months[t.CreatedAt.Format(model.StatementMonthLayout)] = true
+
+		switch t.Category.Type {
+		case model.DESPESA:
+			expenses = expenses.Add(t.Amount)
+		case model.RECEITA:
+			income = income.Add(t.Amount)
+		}
+	}
+
+	history := model.GoalTemplateHistory{Months: len(months)}
+	if history.Months > 0 {
+		history.AverageExpenses = expenses.Div(int64(history.Months))
+		history.AverageIncome = income.Div(int64(history.Months))
+	}
+
+	return history, currency, nil
+}
+
+func buildTemplateGoal(
+	v *validator.Validator,
+	user *model.User,
+	kind model.GoalTemplateKind,
+	input *model.GoalTemplateInput,
+	history model.GoalTemplateHistory,
+	currency string,
+	now time.Time,
+) (*model.Goal, int) {
+	info := goalTemplates[kind]
+	goal := &model.Goal{
+		Name:        info.name,
+		Description: info.description,
+		Color:       info.color,
+		User:        user,
+		Currency:    currency,
+		Status:      model.GoalStatusPending,
+		Deadline:    now.AddDate(0, templateDefaultHorizon, 0),
+	}
+
+	covered := 0
+
+	switch kind {
+	case model.GoalTemplateEmergencyFund:
+		covered = templateDefaultCoverage
+		if input.Months != nil {
+			covered = *input.Months
+		}
+		goal.Amount = history.AverageExpenses.Mul(int64(covered))
+	case model.GoalTemplateVacation:
+		goal.Amount = history.AverageExpenses
+	case model.GoalTemplateThirteenthSalary:
+		goal.Amount = history.AverageIncome
+		goal.Deadline = time.Date(now.Year(), time.December, thirteenthSalaryDeadline, 0, 0, 0, 0, time.UTC)
+		if goal.Deadline.Before(now.AddDate(0, thirteenthSalaryLeadMonth, 0)) {
+			goal.Deadline = goal.Deadline.AddDate(1, 0, 0)
+		}
+	}
+
+	if input.Months != nil {
+		v.Check(kind == model.GoalTemplateEmergencyFund, "months", "is only supported by the EMERGENCY_FUND template")
+	}
+	if input.Amount != nil {
+		goal.Amount = *input.Amount
+		covered = 0
+	}
+	if input.Deadline != nil {
+		if deadline, err := time.Parse(model.GoalDeadlineLayout, *input.Deadline); err == nil {
+			goal.Deadline = deadline
+		}
+	}
+	if input.Name != nil {
+		goal.Name = *input.Name
+	}
+	if input.Description != nil {
+		goal.Description = *input.Description
+	}
+	if input.Color != nil {
+		goal.Color = *input.Color
+	}
+
+	v.Check(goal.Amount.IsPositive(), "amount", "could not be computed from your transaction history and must be provided")
+	v.Check(goal.Deadline.After(now), "deadline", "must be in the future")
+	if !v.Valid() {
+		return nil, 0
+	}
+
+	goal.Installments = goalInstallments(goal, now)
+	return goal, covered
+}
+
+func templatePreview(
+	kind model.GoalTemplateKind,
+	goal *model.Goal,
+	covered int,
+	history model.GoalTemplateHistory,
+) *model.GoalTemplatePreview {
+	return &model.GoalTemplatePreview{
+		Kind:          kind.String(),
+		Goal:          goal.ToDTO(),
+		MonthsCovered: covered,
+		History:       history,
+	}
+}
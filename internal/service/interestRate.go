@@ -0,0 +1,38 @@
+package service
+
+import (
+	"financas/internal/model"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+)
+
+type InterestRateService struct {
+	rate repository.InterestRateRepositoryInterface
+}
+
+type InterestRateServiceInterface interface {
+	GetAll(userID int64) ([]*model.InterestRate, error)
+	Save(v *validator.Validator, rate *model.InterestRate) error
+	Delete(id, userID int64) error
+}
+
+func NewInterestRateService(rate repository.InterestRateRepositoryInterface) *InterestRateService {
+	return &InterestRateService{rate: rate}
+}
+
+func (s *InterestRateService) GetAll(userID int64) ([]*model.InterestRate, error) {
+	return s.rate.GetAll(userID, 0)
+}
+
+func (s *InterestRateService) Save(v *validator.Validator, rate *model.InterestRate) error {
+	if rate.ValidateInterestRate(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.rate.Upsert(rate)
+}
+
+func (s *InterestRateService) Delete(id, userID int64) error {
+	return s.rate.Delete(id, userID)
+}
@@ -0,0 +1,693 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	"financas/utils"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const quantityEpsilon = 1e-8
+
+type InvestmentService struct {
+	investment   repository.InvestmentRepositoryInterface
+	exchangeRate ExchangeRateServiceInterface
+	db           *sql.DB
+}
+
+type InvestmentServiceInterface interface {
+	GetAll(v *validator.Validator, name string, userID int64, f filters.Filters) ([]*model.Investment, filters.Metadata, error)
+	GetByID(id, userID int64) (*model.Investment, *model.Position, error)
+	Insert(v *validator.Validator, investment *model.Investment) error
+	Update(v *validator.Validator, investment *model.Investment) error
+	Delete(id, userID int64) error
+	AddOperation(v *validator.Validator, investmentID, userID int64, operation *model.InvestmentOperation) error
+	DeleteOperation(v *validator.Validator, id, investmentID, userID int64) error
+	AddPrice(v *validator.Validator, investmentID, userID int64, price *model.InvestmentPrice) error
+	ImportPrices(v *validator.Validator, investmentID, userID int64, r io.Reader) ([]*model.InvestmentPrice, error)
+	DeletePrice(id, investmentID, userID int64) error
+	GetPositions(userID int64, date *time.Time) ([]*model.Position, error)
+	GetReturns(v *validator.Validator, userID int64, startDate, endDate *time.Time) (*model.PortfolioReport, error)
+}
+
+func NewInvestmentService(
+	investment repository.InvestmentRepositoryInterface,
+	exchangeRate ExchangeRateServiceInterface,
+	db *sql.DB,
+) *InvestmentService {
+	return &InvestmentService{
+		investment:   investment,
+		exchangeRate: exchangeRate,
+		db:           db,
+	}
+}
+
+func (s *InvestmentService) GetAll(v *validator.Validator, name string, userID int64, f filters.Filters) ([]*model.Investment, filters.Metadata, error) {
+	if filters.ValidateFilters(v, f); !v.Valid() {
+		return nil, filters.Metadata{}, e.ErrInvalidData
+	}
+
+	return s.investment.GetAll(name, userID, f)
+}
+
+func (s *InvestmentService) GetByID(id, userID int64) (*model.Investment, *model.Position, error) {
+	investment, err := s.get(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return investment, calculatePosition(investment, today()), nil
+}
+
+func (s *InvestmentService) Insert(v *validator.Validator, investment *model.Investment) error {
+	if investment.ValidateInvestment(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.investment.Insert(investment)
+}
+
+func (s *InvestmentService) Update(v *validator.Validator, investment *model.Investment) error {
+	if investment.ValidateInvestment(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.investment.Update(investment)
+}
+
+func (s *InvestmentService) Delete(id, userID int64) error {
+	return s.investment.Delete(id, userID)
+}
+
+func (s *InvestmentService) AddOperation(
+	v *validator.Validator,
+	investmentID, userID int64,
+	operation *model.InvestmentOperation,
+) error {
+	if operation.ValidateInvestmentOperation(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	investment, err := s.get(investmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	operation.InvestmentID = investment.ID
+	operation.CalculateAmount()
+
+	operations := append(append([]*model.InvestmentOperation{}, investment.Operations...), operation)
+	sortOperations(operations)
+
+	if v.Check(holdingsAreValid(operations), "quantity", "must not exceed the quantity held on the given date"); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.investment.InsertOperation(operation)
+}
+
+func (s *InvestmentService) DeleteOperation(v *validator.Validator, id, investmentID, userID int64) error {
+	investment, err := s.get(investmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	operations := make([]*model.InvestmentOperation, 0, len(investment.Operations))
+	for _, operation := range investment.Operations {
+		if operation.ID != id {
+			operations = append(operations, operation)
+		}
+	}
+
+	if v.Check(holdingsAreValid(operations), "operation", "removing it would leave later sells without enough quantity"); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.investment.DeleteOperation(id, investmentID)
+}
+
+func (s *InvestmentService) AddPrice(v *validator.Validator, investmentID, userID int64, price *model.InvestmentPrice) error {
+	if price.ValidateInvestmentPrice(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	if _, err := s.investment.GetByID(investmentID, userID); err != nil {
+		return err
+	}
+
+	price.InvestmentID = investmentID
+
+	return utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		return s.investment.UpsertPrice(price, tx)
+	})
+}
+
+func (s *InvestmentService) ImportPrices(v *validator.Validator, investmentID, userID int64, r io.Reader) ([]*model.InvestmentPrice, error) {
+	if _, err := s.investment.GetByID(investmentID, userID); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	prices := []*model.InvestmentPrice{}
+	line := 0
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		line++
+		key := fmt.Sprintf("line_%d", line)
+
+		if err != nil {
+			v.AddError(key, "must have the columns date and price")
+			return nil, e.ErrInvalidData
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+
+		date, err := time.Parse(model.DateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			v.AddError(key, "date must be in the format YYYY-MM-DD")
+			continue
+		}
+
+		value, err := money.Parse(strings.TrimSpace(record[1]))
+		if err != nil {
+			v.AddError(key, "price must be a number")
+			continue
+		}
+
+		price := &model.InvestmentPrice{
+			InvestmentID: investmentID,
+			Date:         date,
+			Price:        value,
+		}
+
+		lineValidator := validator.New()
+		if price.ValidateInvestmentPrice(lineValidator); !lineValidator.Valid() {
+			for field, message := range lineValidator.Errors {
+				v.AddError(key, fmt.Sprintf("%s %s", field, message))
+			}
+			continue
+		}
+
+		prices = append(prices, price)
+	}
+
+	v.Check(line > 0, "file", "must not be empty")
+
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	err := utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		for _, price := range prices {
+			if err := s.investment.UpsertPrice(price, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func (s *InvestmentService) DeletePrice(id, investmentID, userID int64) error {
+	if _, err := s.investment.GetByID(investmentID, userID); err != nil {
+		return err
+	}
+
+	return s.investment.DeletePrice(id, investmentID)
+}
+
+func (s *InvestmentService) GetPositions(userID int64, date *time.Time) ([]*model.Position, error) {
+	at := today()
+	if date != nil {
+		at = *date
+	}
+
+	investments, err := s.investment.GetAllWithHistory(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := []*model.Position{}
+	for _, investment := range investments {
+		position := calculatePosition(investment, at)
+		if position.Quantity > quantityEpsilon || !position.RealizedGain.IsZero() || !position.Dividends.IsZero() {
+			positions = append(positions, position)
+		}
+	}
+
+	return positions, nil
+}
+
+func (s *InvestmentService) GetReturns(
+	v *validator.Validator,
+	userID int64,
+	startDate, endDate *time.Time,
+) (*model.PortfolioReport, error) {
+	end := today()
+	if endDate != nil {
+		end = dayOf(*endDate)
+	}
+
+	start := time.Date(end.Year(), end.Month()-11, 1, 0, 0, 0, 0, time.UTC)
+	if startDate != nil {
+		start = *startDate
+	}
+
+	if v.Check(!start.After(end), "start", e.ErrStartDateAfterEndDate.Error()); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	investments, err := s.investment.GetAllWithHistory(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := s.exchangeRate.GetConverter(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.PortfolioReport{
+		Currency:  converter.Base,
+		StartDate: start.Format(model.DateLayout),
+		EndDate:   end.Format(model.DateLayout),
+		Assets:    []*model.InvestmentReturn{},
+		Positions: []*model.Position{},
+	}
+
+	opening := start.AddDate(0, 0, -1)
+	total := &model.InvestmentReturn{Name: "TOTAL", Currency: converter.Base}
+	totalStart := 0.0
+	totalFlows := map[time.Time]float64{}
+	totalValues := map[time.Time]float64{}
+
+	for _, investment := range investments {
+		if len(investment.Operations) == 0 || investment.Operations[0].Date.After(end) {
+			continue
+		}
+
+		result := &model.InvestmentReturn{
+			InvestmentID: investment.ID,
+			Ticker:       investment.Ticker,
+			Name:         investment.Name,
+			Currency:     investment.Currency,
+			StartValue:   marketValue(investment, opening),
+			EndValue:     marketValue(investment, end),
+		}
+
+		flows := map[time.Time]float64{}
+		for _, operation := range investment.Operations {
+			if operation.Date.Before(start) || operation.Date.After(end) {
+				continue
+			}
+
+			date := dayOf(operation.Date)
+			flow, err := converter.Convert(operation.Amount, investment.Currency, operation.Date)
+			if err != nil {
+				return nil, err
+			}
+
+			switch operation.Type {
+			case model.OperationBuy:
+				result.NetContributions = result.NetContributions.Add(operation.Amount)
+				flows[date] += operation.Amount.Float64()
+				totalFlows[date] += flow.Float64()
+				total.NetContributions = total.NetContributions.Add(flow)
+			case model.OperationSell:
+				result.NetContributions = result.NetContributions.Sub(operation.Amount)
+				flows[date] -= operation.Amount.Float64()
+				totalFlows[date] -= flow.Float64()
+				total.NetContributions = total.NetContributions.Sub(flow)
+			case model.OperationDividend:
+				result.Dividends = result.Dividends.Add(operation.Amount)
+				flows[date] -= operation.Amount.Float64()
+				totalFlows[date] -= flow.Float64()
+				total.Dividends = total.Dividends.Add(flow)
+			}
+		}
+
+		if result.StartValue.IsZero() && len(flows) == 0 {
+			continue
+		}
+
+		result.Gain = result.EndValue.Sub(result.StartValue).Sub(result.NetContributions).Add(result.Dividends)
+		result.TimeWeightedReturn, result.MoneyWeightedReturn, result.AnnualizedMWR = investmentReturns(
+			opening,
+			result.StartValue.Float64(),
+			returnPoints(end, flows, func(date time.Time) float64 {
+				return marketValue(investment, date).Float64()
+			}),
+		)
+		report.Assets = append(report.Assets, result)
+
+		startValue, err := converter.Convert(result.StartValue, investment.Currency, opening)
+		if err != nil {
+			return nil, err
+		}
+		endValue, err := converter.Convert(result.EndValue, investment.Currency, end)
+		if err != nil {
+			return nil, err
+		}
+
+		totalStart += startValue.Float64()
+		total.StartValue = total.StartValue.Add(startValue)
+		total.EndValue = total.EndValue.Add(endValue)
+		totalValues[end] += endValue.Float64()
+	}
+
+	for date := range totalFlows {
+		if date.Equal(end) {
+			continue
+		}
+
+		value := 0.0
+		for _, investment := range investments {
+			converted, err := converter.Convert(marketValue(investment, date), investment.Currency, date)
+			if err != nil {
+				return nil, err
+			}
+			value += converted.Float64()
+		}
+		totalValues[date] = value
+	}
+
+	total.Gain = total.EndValue.Sub(total.StartValue).Sub(total.NetContributions).Add(total.Dividends)
+	total.TimeWeightedReturn, total.MoneyWeightedReturn, total.AnnualizedMWR = investmentReturns(
+		opening,
+		totalStart,
+		returnPoints(end, totalFlows, func(date time.Time) float64 {
+			return totalValues[date]
+		}),
+	)
+	report.Total = total
+
+	for _, investment := range investments {
+		position := calculatePosition(investment, end)
+		if position.Quantity > quantityEpsilon {
+			report.Positions = append(report.Positions, position)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *InvestmentService) get(id, userID int64) (*model.Investment, error) {
+	investment, err := s.investment.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	investment.Operations, err = s.investment.GetOperations(investment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	investment.Prices, err = s.investment.GetPrices(investment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return investment, nil
+}
+
+func today() time.Time {
+	return dayOf(time.Now().UTC())
+}
+
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sortOperations(operations []*model.InvestmentOperation) {
+	sort.SliceStable(operations, func(i, j int) bool {
+		return operations[i].Date.Before(operations[j].Date)
+	})
+}
+
+func holdingsAreValid(operations []*model.InvestmentOperation) bool {
+	quantity := 0.0
+	for _, operation := range operations {
+		switch operation.Type {
+		case model.OperationBuy:
+			quantity += operation.Quantity
+		case model.OperationSell:
+			quantity -= operation.Quantity
+		}
+
+		if quantity < -quantityEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+func calculatePosition(investment *model.Investment, date time.Time) *model.Position {
+	position := &model.Position{
+		InvestmentID: investment.ID,
+		Ticker:       investment.Ticker,
+		Name:         investment.Name,
+		Currency:     investment.Currency,
+	}
+
+	for _, operation := range investment.Operations {
+		if operation.Date.After(date) {
+			break
+		}
+
+		switch operation.Type {
+		case model.OperationBuy:
+			position.Quantity += operation.Quantity
+			position.CostBasis = position.CostBasis.Add(operation.Amount)
+		case model.OperationSell:
+			if position.Quantity <= quantityEpsilon {
+				continue
+			}
+
+			cost := position.CostBasis.MulRate(math.Min(operation.Quantity/position.Quantity, 1))
+			position.RealizedGain = position.RealizedGain.Add(operation.Amount.Sub(cost))
+			position.CostBasis = position.CostBasis.Sub(cost)
+			position.Quantity -= operation.Quantity
+		case model.OperationDividend:
+			position.Dividends = position.Dividends.Add(operation.Amount)
+		}
+
+		if position.Quantity <= quantityEpsilon {
+			position.Quantity = 0
+			position.CostBasis = money.Zero
+		}
+	}
+
+	if position.Quantity > 0 {
+		position.AverageCost = position.CostBasis.DivRate(position.Quantity)
+	}
+
+	if price, priceDate, ok := priceAt(investment, date); ok {
+		position.MarketPrice = price
+		position.PriceDate = priceDate.Format(model.DateLayout)
+		position.MarketValue = price.MulRate(position.Quantity)
+		position.UnrealizedGain = position.MarketValue.Sub(position.CostBasis)
+	}
+
+	return position
+}
+
+func priceAt(investment *model.Investment, date time.Time) (money.Money, time.Time, bool) {
+	var (
+		price money.Money
+		found time.Time
+		ok    bool
+	)
+
+	for _, p := range investment.Prices {
+		if p.Date.After(date) {
+			break
+		}
+		price, found, ok = p.Price, p.Date, true
+	}
+
+	for _, operation := range investment.Operations {
+		if operation.Date.After(date) {
+			break
+		}
+		if operation.Type != model.OperationDividend && operation.Date.After(found) {
+			price, found, ok = operation.Price, operation.Date, true
+		}
+	}
+
+	return price, found, ok
+}
+
+func quantityAt(investment *model.Investment, date time.Time) float64 {
+	quantity := 0.0
+	for _, operation := range investment.Operations {
+		if operation.Date.After(date) {
+			break
+		}
+
+		switch operation.Type {
+		case model.OperationBuy:
+			quantity += operation.Quantity
+		case model.OperationSell:
+			quantity -= operation.Quantity
+		}
+	}
+	return math.Max(quantity, 0)
+}
+
+func marketValue(investment *model.Investment, date time.Time) money.Money {
+	quantity := quantityAt(investment, date)
+	if quantity <= quantityEpsilon {
+		return money.Zero
+	}
+
+	price, _, ok := priceAt(investment, date)
+	if !ok {
+		return money.Zero
+	}
+	return price.MulRate(quantity)
+}
+
+type returnPoint struct {
+	date  time.Time
+	flow  float64
+	value float64
+}
+
+func returnPoints(end time.Time, flows map[time.Time]float64, value func(time.Time) float64) []returnPoint {
+	points := make([]returnPoint, 0, len(flows)+1)
+	for date, flow := range flows {
+		points = append(points, returnPoint{date: date, flow: flow, value: value(date)})
+	}
+
+	if _, exist := flows[end]; !exist {
+		points = append(points, returnPoint{date: end, value: value(end)})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].date.Before(points[j].date)
+	})
+	return points
+}
+
+func investmentReturns(opening time.Time, startValue float64, points []returnPoint) (twr, mwr, annualized *float64) {
+	twr = timeWeightedReturn(startValue, points)
+
+	rate, days, ok := moneyWeightedReturn(opening, startValue, points)
+	if !ok {
+		return twr, nil, nil
+	}
+
+	period := roundPercent(rate)
+	mwr = &period
+
+	if days >= 365 {
+		annual := roundPercent(math.Pow(1+rate, 365/days) - 1)
+		annualized = &annual
+	}
+
+	return twr, mwr, annualized
+}
+
+func timeWeightedReturn(startValue float64, points []returnPoint) *float64 {
+	growth := 1.0
+	previous := startValue
+	measured := false
+
+	for _, point := range points {
+		if previous > 0 {
+			growth *= (point.value - point.flow) / previous
+			measured = true
+		}
+		previous = point.value
+	}
+
+	if !measured {
+		return nil
+	}
+
+	result := roundPercent(growth - 1)
+	return &result
+}
+
+func moneyWeightedReturn(opening time.Time, startValue float64, points []returnPoint) (float64, float64, bool) {
+	if len(points) == 0 {
+		return 0, 0, false
+	}
+
+	days := points[len(points)-1].date.Sub(opening).Hours() / 24
+	if days <= 0 {
+		return 0, 0, false
+	}
+
+	type cashFlow struct {
+		weight float64
+		amount float64
+	}
+
+	flows := []cashFlow{{weight: 0, amount: -startValue}}
+	for i, point := range points {
+		amount := -point.flow
+		if i == len(points)-1 {
+			amount += point.value
+		}
+		flows = append(flows, cashFlow{weight: point.date.Sub(opening).Hours() / 24 / days, amount: amount})
+	}
+
+	npv := func(rate float64) float64 {
+		total := 0.0
+		for _, flow := range flows {
+			total += flow.amount / math.Pow(1+rate, flow.weight)
+		}
+		return total
+	}
+
+	low, high := -0.9999, 1.0
+	for npv(low)*npv(high) > 0 && high < 1e6 {
+		high *= 2
+	}
+	if npv(low)*npv(high) > 0 {
+		return 0, 0, false
+	}
+
+	for range 200 {
+		mid := (low + high) / 2
+		if npv(low)*npv(mid) <= 0 {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+
+	return (low + high) / 2, days, true
+}
+
+func roundPercent(rate float64) float64 {
+	return math.Round(rate*10000) / 100
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/filters"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"math"
+)
+
+type LoanService struct {
+	loan        repository.LoanRepositoryInterface
+	transaction repository.TransactionRepositoryInterface
+}
+
+type LoanServiceInterface interface {
+	GetAll(v *validator.Validator, name string, userID int64, f filters.Filters) ([]*model.Loan, filters.Metadata, error)
+	GetSchedule(id, userID int64) (*model.LoanSchedule, error)
+	Simulate(v *validator.Validator, loan *model.Loan) (*model.LoanSchedule, error)
+	Insert(v *validator.Validator, loan *model.Loan) error
+	Update(v *validator.Validator, loan *model.Loan) error
+	Delete(id, userID int64) error
+	AddPrepayment(v *validator.Validator, loanID, userID int64, prepayment *model.LoanPrepayment) (*model.LoanSchedule, error)
+	DeletePrepayment(id, loanID, userID int64) error
+	PayInstallment(v *validator.Validator, loanID, userID int64, number int, transactionID int64) error
+	UnpayInstallment(loanID, userID int64, number int) error
+}
+
+func NewLoanService(
+	loan repository.LoanRepositoryInterface,
+	transaction repository.TransactionRepositoryInterface,
+) *LoanService {
+	return &LoanService{
+		loan:        loan,
+		transaction: transaction,
+	}
+}
+
+func (s *LoanService) GetAll(v *validator.Validator, name string, userID int64, f filters.Filters) ([]*model.Loan, filters.Metadata, error) {
+	if filters.ValidateFilters(v, f); !v.Valid() {
+		return nil, filters.Metadata{}, e.ErrInvalidData
+	}
+
+	return s.loan.GetAll(name, userID, f)
+}
+
+func (s *LoanService) GetSchedule(id, userID int64) (*model.LoanSchedule, error) {
+	loan, err := s.get(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildLoanSchedule(loan), nil
+}
+
+func (s *LoanService) Simulate(v *validator.Validator, loan *model.Loan) (*model.LoanSchedule, error) {
+	if loan.ValidateLoan(v); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	return buildLoanSchedule(loan), nil
+}
+
+func (s *LoanService) Insert(v *validator.Validator, loan *model.Loan) error {
+	if loan.ValidateLoan(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.loan.Insert(loan)
+}
+
+func (s *LoanService) Update(v *validator.Validator, loan *model.Loan) error {
+	if loan.ValidateLoan(v); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	current, err := s.get(loan.ID, loan.User.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(current.Payments) > 0 {
+		message := "must not change once installments have been paid"
+		v.Check(loan.System == current.System, "system", message)
+		v.Check(loan.Principal == current.Principal, "principal", message)
+		v.Check(loan.AnnualRate == current.AnnualRate, "annual_rate", message)
+		v.Check(loan.Term == current.Term, "term", message)
+		v.Check(loan.StartDate.Equal(current.StartDate), "start_date", message)
+		v.Check(loan.Currency == current.Currency, "currency", message)
+		if !v.Valid() {
+			return e.ErrInvalidData
+		}
+	}
+
+	return s.loan.Update(loan)
+}
+
+func (s *LoanService) Delete(id, userID int64) error {
+	return s.loan.Delete(id, userID)
+}
+
+func (s *LoanService) AddPrepayment(
+	v *validator.Validator,
+	loanID, userID int64,
+	prepayment *model.LoanPrepayment,
+) (*model.LoanSchedule, error) {
+	if prepayment.ValidateLoanPrepayment(v); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	loan, err := s.get(loanID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastPaid := 0
+	for _, payment := range loan.Payments {
+		lastPaid = max(lastPaid, payment.Installment)
+	}
+
+	v.Check(!prepayment.Date.Before(loan.StartDate), "date", "must not be before the first installment due date")
+	if lastPaid > 0 {
+		v.Check(!prepayment.Date.Before(loan.DueDate(lastPaid)), "date", "must not be before the last paid installment")
+	}
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	prepayment.LoanID = loan.ID
+	loan.Prepayments = insertPrepayment(loan.Prepayments, prepayment)
+
+	requested := money.Zero
+	for _, p := range loan.Prepayments {
+		requested = requested.Add(p.Amount)
+	}
+
+	schedule := buildLoanSchedule(loan)
+	if v.Check(schedule.TotalPrepaid == requested, "amount", "must not exceed the outstanding balance at the given date"); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	if err := s.loan.InsertPrepayment(prepayment); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *LoanService) DeletePrepayment(id, loanID, userID int64) error {
+	if _, err := s.loan.GetByID(loanID, userID); err != nil {
+		return err
+	}
+
+	return s.loan.DeletePrepayment(id, loanID)
+}
+
+func (s *LoanService) PayInstallment(v *validator.Validator, loanID, userID int64, number int, transactionID int64) error {
+	loan, err := s.get(loanID, userID)
+	if err != nil {
+		return err
+	}
+
+	schedule := buildLoanSchedule(loan)
+	v.Check(number >= 1 && number <= len(schedule.Installments), "installment", "must be within the loan schedule")
+	v.Check(transactionID > 0, "transaction_id", "must be provided")
+	if !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	transaction, err := s.transaction.GetByID(transactionID, userID)
+	if err != nil {
+		if errors.Is(err, e.ErrRecordNotFound) {
+			v.AddError("transaction_id", "must reference an existing transaction")
+			return e.ErrInvalidData
+		}
+		return err
+	}
+
+	if v.Check(transaction.Category.Type == model.DESPESA, "transaction_id", "must reference an expense transaction"); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	return s.loan.InsertPayment(&model.LoanPayment{
+		LoanID:        loan.ID,
+		Installment:   number,
+		TransactionID: transaction.ID,
+	})
+}
+
+func (s *LoanService) UnpayInstallment(loanID, userID int64, number int) error {
+	if _, err := s.loan.GetByID(loanID, userID); err != nil {
+		return err
+	}
+
+	return s.loan.DeletePayment(loanID, number)
+}
+
+func (s *LoanService) get(id, userID int64) (*model.Loan, error) {
+	loan, err := s.loan.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loan.Prepayments, err = s.loan.GetPrepayments(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loan.Payments, err = s.loan.GetPayments(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func buildLoanSchedule(loan *model.Loan) *model.LoanSchedule {
+	rate := monthlyRate(loan.AnnualRate)
+	installments := amortizationSchedule(loan, rate)
+
+	paid := make(map[int]int64, len(loan.Payments))
+	for _, payment := range loan.Payments {
+		paid[payment.Installment] = payment.TransactionID
+	}
+
+	schedule := &model.LoanSchedule{
+		Loan:         loan.ToDTO(),
+		MonthlyRate:  math.Round(rate*1e6) / 1e4,
+		Term:         len(installments),
+		Prepayments:  prepaymentDTOs(loan.Prepayments),
+		Installments: installments,
+	}
+
+	for _, installment := range installments {
+		schedule.TotalPaid = schedule.TotalPaid.Add(installment.Payment).Add(installment.Prepayment)
+		schedule.TotalInterest = schedule.TotalInterest.Add(installment.Interest)
+		schedule.TotalPrepaid = schedule.TotalPrepaid.Add(installment.Prepayment)
+
+		if transactionID, ok := paid[installment.Number]; ok {
+			installment.Paid = true
+			installment.TransactionID = &transactionID
+			schedule.PaidInstallments++
+			continue
+		}
+
+		schedule.OutstandingDebt = schedule.OutstandingDebt.Add(installment.Amortization)
+		if schedule.NextInstallment == nil {
+			schedule.NextInstallment = installment
+		}
+	}
+
+	return schedule
+}
+
+func amortizationSchedule(loan *model.Loan, rate float64) []*model.LoanInstallment {
+	balance := loan.Principal
+	remaining := loan.Term
+	fixed := fixedInstallment(loan.System, balance, remaining, rate)
+
+	prepayments := loan.Prepayments
+	installments := []*model.LoanInstallment{}
+
+	for number := 1; balance.IsPositive() && number <= model.MaxLoanTerm; number++ {
+		interest := balance.MulRate(rate)
+
+		amortization := fixed
+		if loan.System == model.AmortizationPrice {
+			amortization = fixed.Sub(interest)
+		}
+		if remaining <= 1 || amortization > balance {
+			amortization = balance
+		}
+		amortization = money.Max(amortization, money.Zero)
+
+		balance = balance.Sub(amortization)
+		remaining--
+
+		installment := &model.LoanInstallment{
+			Number:       number,
+			DueDate:      loan.DueDate(number).Format(model.DateLayout),
+			Amortization: amortization,
+			Interest:     interest,
+			Payment:      amortization.Add(interest),
+		}
+
+		next := loan.DueDate(number + 1)
+		for len(prepayments) > 0 && prepayments[0].Date.Before(next) && balance.IsPositive() {
+			prepayment := prepayments[0]
+			prepayments = prepayments[1:]
+
+			amount := money.Min(prepayment.Amount, balance)
+			balance = balance.Sub(amount)
+			installment.Prepayment = installment.Prepayment.Add(amount)
+
+			if !balance.IsPositive() {
+				break
+			}
+
+			switch prepayment.Mode {
+			case model.PrepaymentReduceInstallment:
+				fixed = fixedInstallment(loan.System, balance, remaining, rate)
+			case model.PrepaymentReduceTerm:
+				remaining = remainingTerm(loan.System, balance, fixed, rate)
+			}
+		}
+
+		installment.Balance = balance
+		installments = append(installments, installment)
+	}
+
+	return installments
+}
+
+func monthlyRate(annualRate float64) float64 {
+	return math.Pow(1+annualRate/100, 1.0/12) - 1
+}
+
+func fixedInstallment(system model.AmortizationSystem, balance money.Money, term int, rate float64) money.Money {
+	if term <= 0 {
+		return balance
+	}
+	if system == model.AmortizationSAC || rate == 0 {
+		return balance.Div(int64(term))
+	}
+
+	return money.FromFloat(balance.Float64() * rate / (1 - math.Pow(1+rate, -float64(term))))
+}
+
+func remainingTerm(system model.AmortizationSystem, balance, fixed money.Money, rate float64) int {
+	if !fixed.IsPositive() {
+		return 1
+	}
+
+	var term float64
+	if system == model.AmortizationSAC || rate == 0 {
+		term = balance.Float64() / fixed.Float64()
+	} else {
+		ratio := balance.Float64() * rate / fixed.Float64()
+		if ratio >= 1 {
+			return model.MaxLoanTerm
+		}
+		term = -math.Log(1-ratio) / math.Log(1+rate)
+	}
+
+	return max(int(math.Ceil(term-1e-9)), 1)
+}
+
+func insertPrepayment(prepayments []*model.LoanPrepayment, prepayment *model.LoanPrepayment) []*model.LoanPrepayment {
+	result := make([]*model.LoanPrepayment, 0, len(prepayments)+1)
+	inserted := false
+
+	for _, p := range prepayments {
+		if !inserted && prepayment.Date.Before(p.Date) {
+			result = append(result, prepayment)
+			inserted = true
+		}
+		result = append(result, p)
+	}
+
+	if !inserted {
+		result = append(result, prepayment)
+	}
+	return result
+}
+
+func prepaymentDTOs(prepayments []*model.LoanPrepayment) []*model.LoanPrepaymentDTO {
+	dtos := make([]*model.LoanPrepaymentDTO, 0, len(prepayments))
+	for _, prepayment := range prepayments {
+		dtos = append(dtos, prepayment.ToDTO())
+	}
+	return dtos
+}
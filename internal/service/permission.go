@@ -0,0 +1,22 @@
+package service
+
+import (
+	"financas/internal/model"
+	"financas/internal/repository"
+)
+
+type PermissionService struct {
+	permission repository.PermissionRepositoryInterface
+}
+
+type PermissionServiceInterface interface {
+	GetAllForUser(userID int64) (model.Permissions, error)
+}
+
+func NewPermissionService(p repository.PermissionRepositoryInterface) *PermissionService {
+	return &PermissionService{permission: p}
+}
+
+func (s *PermissionService) GetAllForUser(userID int64) (model.Permissions, error) {
+	return s.permission.GetAllForUser(userID)
+}
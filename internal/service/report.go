@@ -1,15 +1,20 @@
 package service
 
 import (
+	"bytes"
 	"financas/internal/model"
-	"financas/internal/model/filters"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	"financas/internal/xlsx"
+	e "financas/utils/errors"
 	"financas/utils/validator"
+	"fmt"
+	"sort"
 	"time"
 )
 
 type ReportService struct {
-	transaction TransactionServiceInterface
-	category    CategoryServiceInterface
+	report repository.ReportRepositoryInterface
 }
 
 type ReportServiceInterface interface {
@@ -17,22 +22,19 @@ type ReportServiceInterface interface {
 		v *validator.Validator,
 		userID int64,
 		startDate, endDate *time.Time,
-		f filters.Filters,
 	) (*model.FinancialSummary, error)
 
 	GetCategoryReport(
 		v *validator.Validator,
 		userID int64,
 		startDate, endDate *time.Time,
-		f filters.Filters,
 	) ([]model.CategorySummary, error)
 
 	GetIncomeVsExpenses(
 		v *validator.Validator,
 		userID int64,
 		startDate, endDate *time.Time,
-		f filters.Filters,
-	) (map[string]float64, error)
+	) (map[string]money.Money, error)
 
 	GetTopCategories(
 		v *validator.Validator,
@@ -40,14 +42,43 @@ type ReportServiceInterface interface {
 		startDate, endDate *time.Time,
 		limit int,
 		categoryType model.TypeCategoria,
-		f filters.Filters,
 	) ([]model.CategorySummary, error)
+
+	GetPeriodComparison(
+		v *validator.Validator,
+		userID int64,
+		preset string,
+		startDate, endDate *time.Time,
+		compareStartDate, compareEndDate *time.Time,
+		limit int,
+	) (*model.PeriodComparison, error)
+
+	GetTimeSeries(
+		v *validator.Validator,
+		userID int64,
+		granularity string,
+		startDate, endDate *time.Time,
+		categoryID int64,
+	) (*model.TimeSeries, error)
+
+	ExportXLSX(
+		v *validator.Validator,
+		userID int64,
+		startDate, endDate *time.Time,
+	) ([]byte, error)
+
+	GetMonthlyStatement(
+		v *validator.Validator,
+		userID int64,
+		month time.Time,
+	) (*model.MonthlyStatement, error)
 }
 
-func NewReportService(transactionSvc TransactionServiceInterface, categorySvc CategoryServiceInterface) *ReportService {
+const maxTimeSeriesPoints = 1000
+
+func NewReportService(report repository.ReportRepositoryInterface) *ReportService {
 	return &ReportService{
-		transaction: transactionSvc,
-		category:    categorySvc,
+		report: report,
 	}
 }
 
@@ -56,206 +87,609 @@ func (s *ReportService) GetFinancialSummary(
 	userID int64,
 	startDate,
 	endDate *time.Time,
-	f filters.Filters,
 ) (*model.FinancialSummary, error) {
-	transactions, _, err := s.transaction.GetAllByUserAndCategory(
-		v,
-		"",
-		userID,
-		0,
-		startDate,
-		endDate,
-		f,
-	)
-
+	start, end, err := s.resolvePeriod(v, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 
-	if startDate == nil {
-		temp := time.Now().AddDate(0, -1, 0)
-		startDate = &temp
+	now := time.Now().UTC()
+	trend := &model.TrendRange{
+		Granularity: "month",
+		Start:       time.Date(now.Year(), now.Month()-5, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC),
 	}
 
-	if endDate == nil {
-		temp := time.Now().AddDate(0, 0, 0).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-		endDate = &temp
+	aggregates, err := s.report.GetAggregates(userID, start, end, trend)
+	if err != nil {
+		return nil, err
 	}
 
-	var totalIncome, totalExpenses float64
-	categoryTotals := make(map[int64]*model.CategorySummary)
-
-	for _, transaction := range transactions {
-		amount := transaction.Amount
-		category := transaction.Category
-
-		switch category.Type {
-		case model.RECEITA:
-			totalIncome += amount
-		case model.DESPESA:
-			totalExpenses += amount
-		}
+	summary := s.buildSummary(aggregates, start, end)
+	summary.MonthlyTrends = s.buildMonthlyTrends(aggregates, trend)
 
-		if _, exist := categoryTotals[category.ID]; !exist {
-			categoryTotals[category.ID] = &model.CategorySummary{
-				Category: category.ToDTO(),
-				Total:    0,
-				Count:    0,
-			}
-		}
+	return summary, nil
+}
 
-		categoryTotals[category.ID].Total += amount
-		categoryTotals[category.ID].Count++
+func (s *ReportService) GetCategoryReport(
+	v *validator.Validator,
+	userID int64,
+	startDate, endDate *time.Time,
+) ([]model.CategorySummary, error) {
+	start, end, err := s.resolvePeriod(v, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
 
-	categorySummary := make([]model.CategorySummary, 0, len(categoryTotals))
-	for _, summary := range categoryTotals {
-		var totalForPercentage float64
-		if model.TypeCategoriaFromString(*summary.Category.Type) == model.RECEITA {
-			totalForPercentage = totalIncome
-		} else {
-			totalForPercentage = totalExpenses
-		}
+	aggregates, err := s.report.GetAggregates(userID, start, end, nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if totalForPercentage > 0 {
-			summary.Percentage = (summary.Total / totalForPercentage) * 100
-		}
+	return s.buildSummary(aggregates, start, end).CategorySummary, nil
+}
 
-		categorySummary = append(categorySummary, *summary)
+func (s *ReportService) GetIncomeVsExpenses(
+	v *validator.Validator,
+	userID int64,
+	startDate, endDate *time.Time,
+) (map[string]money.Money, error) {
+	start, end, err := s.resolvePeriod(v, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
 
-	monthlyTrends, err := s.generateMonthlyTrends(v, userID, 6)
+	aggregates, err := s.report.GetAggregates(userID, start, end, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	summary := &model.FinancialSummary{
-		TotalIncome:     totalIncome,
-		TotalExpenses:   totalExpenses,
-		Balance:         totalIncome - totalExpenses,
-		CategorySummary: categorySummary,
-		MonthlyTrends:   monthlyTrends,
-		Period: model.PeriodSummary{
-			StartDate: *startDate,
-			EndDate:   *endDate,
-			Days:      int(endDate.Sub(*startDate).Hours() / 24),
-		},
-	}
+	summary := s.buildSummary(aggregates, start, end)
 
-	return summary, nil
+	return map[string]money.Money{
+		"income":   summary.TotalIncome,
+		"expenses": summary.TotalExpenses,
+		"balance":  summary.Balance,
+	}, nil
 }
 
-func (s *ReportService) GetCategoryReport(
+func (s *ReportService) GetTopCategories(
 	v *validator.Validator,
 	userID int64,
 	startDate, endDate *time.Time,
-	f filters.Filters,
+	limit int,
+	categoryType model.TypeCategoria,
 ) ([]model.CategorySummary, error) {
-	summary, err := s.GetFinancialSummary(v, userID, startDate, endDate, f)
+	allCategories, err := s.GetCategoryReport(v, userID, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
-	return summary.CategorySummary, nil
+
+	filtered := make([]model.CategorySummary, 0)
+	for _, cat := range allCategories {
+		if model.TypeCategoriaFromString(*cat.Category.Type) == categoryType {
+			filtered = append(filtered, cat)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Total > filtered[j].Total
+	})
+
+	if limit > 0 && limit < len(filtered) {
+		filtered = filtered[:limit]
+	}
+
+	return filtered, nil
 }
 
-func (s *ReportService) generateMonthlyTrends(v *validator.Validator, userID int64, months int) ([]model.MonthlyTrend, error) {
-	trends := make([]model.MonthlyTrend, 0, months)
-	now := time.Now()
-
-	for i := months - 1; i >= 0; i-- {
-		monthStart := time.Date(now.Year(), now.Month()-time.Month(i), 1, 0, 0, 0, 0, time.UTC)
-		monthEnd := monthStart.AddDate(0, 1, -1)
-
-		transactions, _, err := s.transaction.GetAllByUserAndCategory(
-			v,
-			"",
-			userID,
-			0,
-			&monthStart,
-			&monthEnd,
-			filters.Filters{
-				Page:         1,
-				PageSize:     100,
-				Sort:         "created_at",
-				SortSafelist: []string{"created_at", "amount", "description"},
-			},
-		)
+func (s *ReportService) GetPeriodComparison(
+	v *validator.Validator,
+	userID int64,
+	preset string,
+	startDate, endDate *time.Time,
+	compareStartDate, compareEndDate *time.Time,
+	limit int,
+) (*model.PeriodComparison, error) {
+	v.Check(validator.In(preset, model.ComparisonPresetMonth, model.ComparisonPresetYTD, model.ComparisonPresetCustom), "preset", "invalid preset value")
+	v.Check(limit >= 0, "limit", "must not be negative")
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
+	var currentStart, currentEnd, previousStart, previousEnd time.Time
+
+	switch preset {
+	case model.ComparisonPresetMonth:
+		currentStart = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		currentEnd = currentStart.AddDate(0, 1, 0)
+		previousStart = currentStart.AddDate(0, -1, 0)
+		previousEnd = currentStart
+
+	case model.ComparisonPresetYTD:
+		currentStart = time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		currentEnd = today.AddDate(0, 0, 1)
+		previousStart = currentStart.AddDate(-1, 0, 0)
+		previousEnd = currentEnd.AddDate(-1, 0, 0)
+
+	default:
+		var err error
+		currentStart, currentEnd, err = s.resolvePeriod(v, startDate, endDate)
 		if err != nil {
 			return nil, err
 		}
 
-		var monthIncome, monthExpenses float64
-		for _, transaction := range transactions {
-			if transaction.Category.Type == model.RECEITA {
-				monthIncome += transaction.Amount
-			} else {
-				monthExpenses += transaction.Amount
+		if compareStartDate != nil || compareEndDate != nil {
+			v.Check(compareStartDate != nil, "compare_start", "must be provided")
+			v.Check(compareEndDate != nil, "compare_end", "must be provided")
+			if !v.Valid() {
+				return nil, e.ErrInvalidData
 			}
-		}
 
-		trend := model.MonthlyTrend{
-			Month:    monthStart.Format("Jan/2006"),
-			Income:   monthIncome,
-			Expenses: monthExpenses,
-			Balance:  monthIncome - monthExpenses,
+			previousStart, previousEnd, err = s.resolvePeriod(v, compareStartDate, compareEndDate)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			previousStart = currentStart.Add(-currentEnd.Sub(currentStart))
+			previousEnd = currentStart
 		}
+	}
 
-		trends = append(trends, trend)
+	current, err := s.report.GetAggregates(userID, currentStart, currentEnd, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return trends, nil
+	previous, err := s.report.GetAggregates(userID, previousStart, previousEnd, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	currentSummary := s.buildSummary(current, currentStart, currentEnd)
+	previousSummary := s.buildSummary(previous, previousStart, previousEnd)
+
+	return s.compareSummaries(currentSummary, previousSummary, limit), nil
 }
 
-func (s *ReportService) GetIncomeVsExpenses(
+func (s *ReportService) GetTimeSeries(
 	v *validator.Validator,
 	userID int64,
+	granularity string,
 	startDate, endDate *time.Time,
-	f filters.Filters,
-) (map[string]float64, error) {
-	summary, err := s.GetFinancialSummary(v, userID, startDate, endDate, f)
+	categoryID int64,
+) (*model.TimeSeries, error) {
+	v.Check(validator.In(granularity, model.Granularities...), "granularity", "invalid granularity value")
+	v.Check(categoryID >= 0, "category_id", "must not be negative")
+	if !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	start, end, err := s.resolvePeriod(v, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 
-	return map[string]float64{
-		"income":   summary.TotalIncome,
-		"expenses": summary.TotalExpenses,
-		"balance":  summary.Balance,
-	}, nil
+	buckets := timeBuckets(granularity, start, end)
+	if v.Check(len(buckets) <= maxTimeSeriesPoints, "granularity", "too many points for the given period"); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	trend := &model.TrendRange{
+		Granularity: granularity,
+		Start:       start,
+		End:         end,
+		CategoryID:  categoryID,
+	}
+
+	aggregates, err := s.report.GetTrend(userID, trend)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[time.Time]*model.PeriodTotal, len(aggregates.Periods))
+	for _, p := range aggregates.Periods {
+		totals[p.Start.UTC()] = p
+	}
+
+	series := &model.TimeSeries{
+		Currency:    aggregates.Currency,
+		Granularity: granularity,
+		Period: model.PeriodSummary{
+			StartDate: start,
+			EndDate:   end.Add(-time.Second),
+			Days:      int(end.Sub(start).Hours() / 24),
+		},
+		Points: make([]model.TimeSeriesPoint, 0, len(buckets)),
+	}
+
+	if categoryID > 0 {
+		series.CategoryID = &categoryID
+	}
+
+	for _, bucket := range buckets {
+		point := model.TimeSeriesPoint{
+			Label: bucketLabel(granularity, bucket),
+			Start: bucket,
+		}
+
+		if p, exist := totals[bucket]; exist {
+			point.Income = p.Income
+			point.Expenses = p.Expenses
+			point.Count = p.Count
+		}
+		point.Balance = point.Income.Sub(point.Expenses)
+
+		series.Points = append(series.Points, point)
+	}
+
+	return series, nil
 }
 
-func (s *ReportService) GetTopCategories(
+func (s *ReportService) ExportXLSX(
 	v *validator.Validator,
 	userID int64,
 	startDate, endDate *time.Time,
-	limit int,
-	categoryType model.TypeCategoria,
-	f filters.Filters,
-) ([]model.CategorySummary, error) {
-	allCategories, err := s.GetCategoryReport(v, userID, startDate, endDate, f)
+) ([]byte, error) {
+	start, end, err := s.resolvePeriod(v, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 
-	filtered := make([]model.CategorySummary, 0)
-	for _, cat := range allCategories {
-		if model.TypeCategoriaFromString(*cat.Category.Type) == categoryType {
-			filtered = append(filtered, cat)
+	summary, err := s.GetFinancialSummary(v, userID, &start, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, _, err := s.report.GetConvertedTransactions(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	workbook := xlsx.New()
+
+	sheet := workbook.AddSheet("Resumo")
+	sheet.SetColumnWidths(24, 18)
+	sheet.AddHeader("Campo", "Valor")
+	sheet.AddRow(xlsx.Text("Moeda"), xlsx.Text(summary.Currency))
+	sheet.AddRow(xlsx.Text("Início"), xlsx.Date(summary.Period.StartDate))
+	sheet.AddRow(xlsx.Text("Fim"), xlsx.Date(summary.Period.EndDate))
+	sheet.AddRow(xlsx.Text("Dias"), xlsx.Integer(summary.Period.Days))
+	sheet.AddRow(xlsx.Text("Receitas"), xlsx.Money(summary.TotalIncome))
+	sheet.AddRow(xlsx.Text("Despesas"), xlsx.Money(summary.TotalExpenses))
+	sheet.AddRow(xlsx.Text("Saldo"), xlsx.Money(summary.Balance))
+
+	sheet = workbook.AddSheet("Categorias")
+	sheet.SetColumnWidths(28, 12, 16, 12, 12)
+	sheet.AddHeader("Categoria", "Tipo", "Total", "Transações", "Percentual")
+	for _, c := range summary.CategorySummary {
+		sheet.AddRow(
+			xlsx.Text(*c.Category.Name),
+			xlsx.Text(*c.Category.Type),
+			xlsx.Money(c.Total),
+			xlsx.Integer(c.Count),
+			xlsx.Percent(c.Percentage),
+		)
+	}
+
+	sheet = workbook.AddSheet("Tendência mensal")
+	sheet.SetColumnWidths(14, 16, 16, 16)
+	sheet.AddHeader("Mês", "Receitas", "Despesas", "Saldo")
+	for _, t := range summary.MonthlyTrends {
+		sheet.AddRow(
+			xlsx.Text(t.Month),
+			xlsx.Money(t.Income),
+			xlsx.Money(t.Expenses),
+			xlsx.Money(t.Balance),
+		)
+	}
+
+	sheet = workbook.AddSheet("Transações")
+	sheet.SetColumnWidths(18, 36, 28, 12, 16, 8)
+	sheet.AddHeader("Data", "Descrição", "Categoria", "Tipo", "Valor", "Moeda")
+	for _, t := range transactions {
+		sheet.AddRow(
+			xlsx.Date(t.CreatedAt),
+			xlsx.Text(t.Description),
+			xlsx.Text(t.Category.Name),
+			xlsx.Text(t.Category.Type.String()),
+			xlsx.Money(t.Amount),
+			xlsx.Text(t.Currency),
+		)
+	}
+
+	var buf bytes.Buffer
+	if err := workbook.Write(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *ReportService) GetMonthlyStatement(
+	v *validator.Validator,
+	userID int64,
+	month time.Time,
+) (*model.MonthlyStatement, error) {
+	now := time.Now().UTC()
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	if v.Check(start.Before(now), "month", "must not be in the future"); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	opening, currency, err := s.report.GetBalance(userID, start)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates, err := s.report.GetAggregates(userID, start, end, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, _, err := s.report.GetConvertedTransactions(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := s.buildSummary(aggregates, start, end)
+
+	return &model.MonthlyStatement{
+		Month:          start,
+		Currency:       currency,
+		OpeningBalance: opening,
+		ClosingBalance: opening.Add(summary.Balance),
+		TotalIncome:    summary.TotalIncome,
+		TotalExpenses:  summary.TotalExpenses,
+		Categories:     summary.CategorySummary,
+		Transactions:   transactions,
+	}, nil
+}
+
+func (s *ReportService) compareSummaries(current, previous *model.FinancialSummary, limit int) *model.PeriodComparison {
+	comparison := &model.PeriodComparison{
+		Currency:              current.Currency,
+		Current:               current.Period,
+		Previous:              previous.Period,
+		IncomeDelta:           current.TotalIncome.Sub(previous.TotalIncome),
+		ExpensesDelta:         current.TotalExpenses.Sub(previous.TotalExpenses),
+		BalanceDelta:          current.Balance.Sub(previous.Balance),
+		Categories:            []model.CategoryComparison{},
+		NewCategories:         []*model.CategoryDTO{},
+		DisappearedCategories: []*model.CategoryDTO{},
+		LargestMovers:         []model.CategoryComparison{},
+	}
+
+	previousByID := make(map[int64]model.CategorySummary, len(previous.CategorySummary))
+	for _, p := range previous.CategorySummary {
+		previousByID[*p.Category.ID] = p
+	}
+
+	for _, c := range current.CategorySummary {
+		currentSummary := c
+		item := model.CategoryComparison{
+			Category: c.Category,
+			Current:  &currentSummary,
+		}
+
+		p, exist := previousByID[*c.Category.ID]
+		if exist {
+			previousSummary := p
+			item.Previous = &previousSummary
+			item.Delta = c.Total.Sub(p.Total)
+			delete(previousByID, *c.Category.ID)
+		} else {
+			item.Delta = c.Total
 		}
+
+		switch {
+		case !exist:
+			item.Status = model.ComparisonStatusNew
+			comparison.NewCategories = append(comparison.NewCategories, c.Category)
+		case item.Delta.IsZero():
+			item.Status = model.ComparisonStatusUnchanged
+		default:
+			item.Status = model.ComparisonStatusChanged
+		}
+
+		if exist && p.Total.IsPositive() {
+			percentage := money.Percent(item.Delta, p.Total)
+			item.DeltaPercentage = &percentage
+		}
+
+		comparison.Categories = append(comparison.Categories, item)
 	}
 
-	for i := 0; i < len(filtered)-1; i++ {
-		for j := i + 1; j < len(filtered); j++ {
-			if filtered[i].Total < filtered[j].Total {
-				filtered[i], filtered[j] = filtered[j], filtered[i]
-			}
+	for _, p := range previous.CategorySummary {
+		if _, remaining := previousByID[*p.Category.ID]; !remaining {
+			continue
 		}
+
+		previousSummary := p
+		percentage := float64(-100)
+		comparison.Categories = append(comparison.Categories, model.CategoryComparison{
+			Category:        p.Category,
+			Previous:        &previousSummary,
+			Delta:           p.Total.Neg(),
+			DeltaPercentage: &percentage,
+			Status:          model.ComparisonStatusDisappeared,
+		})
+		comparison.DisappearedCategories = append(comparison.DisappearedCategories, p.Category)
 	}
 
-	if limit > 0 && limit < len(filtered) {
-		filtered = filtered[:limit]
+	movers := make([]model.CategoryComparison, 0, len(comparison.Categories))
+	for _, c := range comparison.Categories {
+		if !c.Delta.IsZero() {
+			movers = append(movers, c)
+		}
 	}
 
-	return filtered, nil
+	sort.SliceStable(movers, func(i, j int) bool {
+		return movers[i].Delta.Abs() > movers[j].Delta.Abs()
+	})
+
+	if limit > 0 && limit < len(movers) {
+		movers = movers[:limit]
+	}
+	comparison.LargestMovers = movers
+
+	return comparison
+}
+
+func (s *ReportService) resolvePeriod(v *validator.Validator, startDate, endDate *time.Time) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	start := today.AddDate(0, -1, 0)
+	if startDate != nil {
+		start = *startDate
+	}
+
+	end := today.AddDate(0, 0, 1)
+	if endDate != nil {
+		end = endDate.AddDate(0, 0, 1)
+	}
+
+	if v.Check(start.Before(end), "start", e.ErrStartDateAfterEndDate.Error()); !v.Valid() {
+		return time.Time{}, time.Time{}, e.ErrInvalidData
+	}
+
+	return start, end, nil
+}
+
+func (s *ReportService) buildSummary(aggregates *model.ReportAggregates, start, end time.Time) *model.FinancialSummary {
+	var totalIncome, totalExpenses money.Money
+
+	for _, c := range aggregates.Categories {
+		switch c.Category.Type {
+		case model.RECEITA:
+			totalIncome = totalIncome.Add(c.Total)
+		case model.DESPESA:
+			totalExpenses = totalExpenses.Add(c.Total)
+		}
+	}
+
+	categorySummary := make([]model.CategorySummary, 0, len(aggregates.Categories))
+	for _, c := range aggregates.Categories {
+		totalForPercentage := totalExpenses
+		if c.Category.Type == model.RECEITA {
+			totalForPercentage = totalIncome
+		}
+
+		summary := model.CategorySummary{
+			Category: c.Category.ToDTO(),
+			Total:    c.Total,
+			Count:    c.Count,
+		}
+
+		if totalForPercentage.IsPositive() {
+			summary.Percentage = money.Percent(c.Total, totalForPercentage)
+		}
+
+		categorySummary = append(categorySummary, summary)
+	}
+
+	lastDay := end.Add(-time.Second)
+
+	return &model.FinancialSummary{
+		TotalIncome:     totalIncome,
+		TotalExpenses:   totalExpenses,
+		Balance:         totalIncome.Sub(totalExpenses),
+		Currency:        aggregates.Currency,
+		CategorySummary: categorySummary,
+		MonthlyTrends:   []model.MonthlyTrend{},
+		Period: model.PeriodSummary{
+			StartDate: start,
+			EndDate:   lastDay,
+			Days:      int(end.Sub(start).Hours() / 24),
+		},
+	}
+}
+
+func (s *ReportService) buildMonthlyTrends(aggregates *model.ReportAggregates, trend *model.TrendRange) []model.MonthlyTrend {
+	totals := make(map[time.Time]*model.PeriodTotal, len(aggregates.Periods))
+	for _, p := range aggregates.Periods {
+		totals[p.Start.UTC()] = p
+	}
+
+	trends := []model.MonthlyTrend{}
+	for month := trend.Start; month.Before(trend.End); month = month.AddDate(0, 1, 0) {
+		trend := model.MonthlyTrend{
+			Month: month.Format("Jan/2006"),
+		}
+
+		if p, exist := totals[month]; exist {
+			trend.Income = p.Income
+			trend.Expenses = p.Expenses
+		}
+		trend.Balance = trend.Income.Sub(trend.Expenses)
+
+		trends = append(trends, trend)
+	}
+
+	return trends
+}
+
+func truncateToBucket(granularity string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch granularity {
+	case model.GranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case model.GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case model.GranularityQuarter:
+		month := time.Month((int(t.Month())-1)/3*3 + 1)
+		return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+	case model.GranularityYear:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func nextBucket(granularity string, t time.Time) time.Time {
+	switch granularity {
+	case model.GranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case model.GranularityMonth:
+		return t.AddDate(0, 1, 0)
+	case model.GranularityQuarter:
+		return t.AddDate(0, 3, 0)
+	case model.GranularityYear:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func timeBuckets(granularity string, start, end time.Time) []time.Time {
+	buckets := []time.Time{}
+	for bucket := truncateToBucket(granularity, start); bucket.Before(end); bucket = nextBucket(granularity, bucket) {
+		buckets = append(buckets, bucket)
+		if len(buckets) > maxTimeSeriesPoints {
+			break
+		}
+	}
+	return buckets
+}
+
+func bucketLabel(granularity string, t time.Time) string {
+	switch granularity {
+	case model.GranularityMonth:
+		return t.Format("2006-01")
+	case model.GranularityQuarter:
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	case model.GranularityYear:
+		return t.Format("2006")
+	default:
+		return t.Format("2006-01-02")
+	}
 }
@@ -7,29 +7,73 @@ import (
 )
 
 type Service struct {
-	User         UserServiceInterface
-	Auth         AuthServiceInterface
-	Category     CategoryServiceInterface
-	Transaction  TransactionServiceInterface
-	Report       ReportServiceInterface
-	Goal         GoalServiceInterface
-	GoalProgress GoalProgressServiceInterface
+	User          UserServiceInterface
+	Auth          AuthServiceInterface
+	Category      CategoryServiceInterface
+	Transaction   TransactionServiceInterface
+	Report        ReportServiceInterface
+	Goal          GoalServiceInterface
+	GoalProgress  GoalProgressServiceInterface
+	Permission    PermissionServiceInterface
+	Admin         AdminServiceInterface
+	ExchangeRate  ExchangeRateServiceInterface
+	Forecast      ForecastServiceInterface
+	Statement     StatementServiceInterface
+	Chart         ChartServiceInterface
+	Anomaly       AnomalyServiceInterface
+	Subscription  SubscriptionServiceInterface
+	Asset         AssetServiceInterface
+	Loan          LoanServiceInterface
+	Investment    InvestmentServiceInterface
+	GoalSchedule  GoalScheduleServiceInterface
+	GoalMilestone GoalMilestoneServiceInterface
+	InterestRate  InterestRateServiceInterface
+	GoalMember    GoalMemberServiceInterface
+	GoalTemplate  GoalTemplateServiceInterface
 }
 
 func NewService(db *sql.DB, config config.Config) *Service {
 	repository := repository.NewRepository(db)
 	userService := NewUserService(repository.User)
 	categoryService := NewCategoryService(repository.Category, db)
-	transactionService := NewTransactionService(repository.Transaction)
-	goalService := NewGoalService(repository.Goal)
+	goalService := NewGoalService(repository.Goal, repository.InterestRate, db)
+	goalMilestoneService := NewGoalMilestoneService(repository.GoalMilestone, goalService, db)
+	goalProgressService := NewGoalProgressService(
+		repository.GoalProgress,
+		goalService,
+		repository.Goal,
+		repository.Transaction,
+		repository.Category,
+		goalMilestoneService,
+		db,
+	)
+	transactionService := NewTransactionService(repository.Transaction, goalProgressService, db)
+	exchangeRateService := NewExchangeRateService(repository.ExchangeRate, repository.User, db)
+	reportService := NewReportService(repository.Report)
 
 	return &Service{
-		User:         userService,
-		Auth:         NewAuthService(userService, config),
-		Category:     categoryService,
-		Transaction:  transactionService,
-		Report:       NewReportService(transactionService, categoryService),
-		Goal:         goalService,
-		GoalProgress: NewGoalProgressService(repository.GoalProgress, goalService),
+		User:          userService,
+		Auth:          NewAuthService(userService, config),
+		Category:      categoryService,
+		Transaction:   transactionService,
+		Report:        reportService,
+		Goal:          goalService,
+		GoalProgress:  goalProgressService,
+		Permission:    NewPermissionService(repository.Permission),
+		Admin:         NewAdminService(repository.User, repository.Admin),
+		ExchangeRate:  exchangeRateService,
+		Forecast:      NewForecastService(repository.Report, repository.Goal, exchangeRateService),
+		Statement:     NewStatementService(reportService, repository.Statement, repository.User),
+		Chart:         NewChartService(reportService, goalService),
+		Anomaly:       NewAnomalyService(repository.Report),
+		Subscription:  NewSubscriptionService(repository.Report, repository.Subscription),
+		Asset:         NewAssetService(repository.Asset, repository.Report, exchangeRateService),
+		Loan:          NewLoanService(repository.Loan, repository.Transaction),
+		Investment:    NewInvestmentService(repository.Investment, exchangeRateService, db),
+		GoalSchedule:  NewGoalScheduleService(repository.GoalSchedule, goalService, goalProgressService, db),
+		GoalMilestone: goalMilestoneService,
+		InterestRate:  NewInterestRateService(repository.InterestRate),
+		GoalMember:    NewGoalMemberService(repository.GoalMember, goalService, repository.User),
+		GoalTemplate:  NewGoalTemplateService(repository.Report, goalService),
 	}
 }
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/pdf"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"fmt"
+	"time"
+)
+
+var monthNames = [...]string{
+	"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+	"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
+}
+
+type StatementService struct {
+	report    ReportServiceInterface
+	statement repository.StatementRepositoryInterface
+	user      repository.UserRepository
+}
+
+type StatementServiceInterface interface {
+	GetAll(userID int64) ([]*model.Statement, error)
+	GetPDF(v *validator.Validator, userID int64, month time.Time) ([]byte, error)
+	Generate(v *validator.Validator, userID int64, month time.Time) (*model.Statement, error)
+	GenerateForAllUsers(month time.Time) (int, error)
+}
+
+func NewStatementService(
+	report ReportServiceInterface,
+	statement repository.StatementRepositoryInterface,
+	user repository.UserRepository,
+) *StatementService {
+	return &StatementService{
+		report:    report,
+		statement: statement,
+		user:      user,
+	}
+}
+
+func (s *StatementService) GetAll(userID int64) ([]*model.Statement, error) {
+	return s.statement.GetAllByUserID(userID)
+}
+
+func (s *StatementService) GetPDF(v *validator.Validator, userID int64, month time.Time) ([]byte, error) {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	stored, err := s.statement.GetByMonth(userID, month)
+	switch {
+	case err == nil:
+		return stored.Content, nil
+	case !errors.Is(err, e.ErrRecordNotFound):
+		return nil, err
+	}
+
+	return s.render(v, userID, month)
+}
+
+func (s *StatementService) Generate(v *validator.Validator, userID int64, month time.Time) (*model.Statement, error) {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	content, err := s.render(v, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &model.Statement{
+		User:    &model.User{ID: userID},
+		Month:   month,
+		Content: content,
+	}
+
+	if err := s.statement.Upsert(statement); err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+func (s *StatementService) GenerateForAllUsers(month time.Time) (int, error) {
+	ids, err := s.user.GetActivatedIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	errs := []error{}
+
+	for _, id := range ids {
+		if _, err := s.Generate(validator.New(), id, month); err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", id, err))
+			continue
+		}
+		generated++
+	}
+
+	return generated, errors.Join(errs...)
+}
+
+func (s *StatementService) render(v *validator.Validator, userID int64, month time.Time) ([]byte, error) {
+	user, err := s.user.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := s.report.GetMonthlyStatement(v, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := renderStatement(statement, user).Write(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderStatement(st *model.MonthlyStatement, user *model.User) *pdf.Document {
+	const right = pdf.PageWidth - pdf.Margin
+
+	doc := pdf.New()
+
+	doc.Advance(20)
+	doc.Text(pdf.Margin, 18, true, "Extrato mensal")
+	doc.TextRight(right, 12, true, fmt.Sprintf("%s/%d", monthNames[st.Month.Month()-1], st.Month.Year()))
+	doc.Advance(16)
+	doc.Text(pdf.Margin, 10, false, user.Name)
+	doc.TextRight(right, 10, false, user.Email)
+	doc.Advance(10)
+	doc.Line(pdf.Margin, right)
+
+	summary := []struct {
+		label string
+		value money.Money
+	}{
+		{"Saldo inicial", st.OpeningBalance},
+		{"Receitas", st.TotalIncome},
+		{"Despesas", st.TotalExpenses},
+		{"Saldo final", st.ClosingBalance},
+	}
+
+	doc.Advance(10)
+	for i, item := range summary {
+		bold := i == len(summary)-1
+		doc.Advance(16)
+		doc.Text(pdf.Margin, 11, bold, item.label)
+		doc.TextRight(right, 11, bold, formatAmount(item.value, st.Currency))
+	}
+
+	doc.Advance(30)
+	doc.Text(pdf.Margin, 13, true, "Categorias")
+	doc.Advance(18)
+	doc.Text(pdf.Margin, 9, true, "Categoria")
+	doc.Text(300, 9, true, "Tipo")
+	doc.TextRight(410, 9, true, "Transações")
+	doc.TextRight(500, 9, true, "Total")
+	doc.TextRight(right, 9, true, "%")
+	doc.Advance(4)
+	doc.Line(pdf.Margin, right)
+
+	for _, c := range st.Categories {
+		doc.Advance(14)
+		doc.Text(pdf.Margin, 9, false, pdf.Truncate(*c.Category.Name, 9, 250))
+		doc.Text(300, 9, false, *c.Category.Type)
+		doc.TextRight(410, 9, false, fmt.Sprintf("%d", c.Count))
+		doc.TextRight(500, 9, false, c.Total.String())
+		doc.TextRight(right, 9, false, fmt.Sprintf("%.2f", c.Percentage))
+	}
+
+	doc.Advance(30)
+	doc.Text(pdf.Margin, 13, true, "Transações")
+	doc.Advance(18)
+	doc.Text(pdf.Margin, 9, true, "Data")
+	doc.Text(110, 9, true, "Descrição")
+	doc.Text(340, 9, true, "Categoria")
+	doc.TextRight(right, 9, true, "Valor")
+	doc.Advance(4)
+	doc.Line(pdf.Margin, right)
+
+	for _, t := range st.Transactions {
+		value := t.Amount
+		if t.Category.Type == model.DESPESA {
+			value = value.Neg()
+		}
+
+		doc.Advance(14)
+		doc.Text(pdf.Margin, 9, false, t.CreatedAt.Format("02/01/2006"))
+		doc.Text(110, 9, false, pdf.Truncate(t.Description, 9, 220))
+		doc.Text(340, 9, false, pdf.Truncate(t.Category.Name, 9, 130))
+		doc.TextRight(right, 9, false, value.String())
+	}
+
+	if len(st.Transactions) == 0 {
+		doc.Advance(14)
+		doc.Text(pdf.Margin, 9, false, "Nenhuma transação no período.")
+	}
+
+	doc.Advance(30)
+	doc.Text(pdf.Margin, 8, false, fmt.Sprintf("Gerado em %s", time.Now().UTC().Format("02/01/2006 15:04 MST")))
+
+	return doc
+}
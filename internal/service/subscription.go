@@ -0,0 +1,241 @@
+package service
+
+import (
+	"financas/internal/model"
+	"financas/internal/model/money"
+	"financas/internal/repository"
+	e "financas/utils/errors"
+	"financas/utils/validator"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const subscriptionLookbackMonths = 13
+
+var subscriptionCadences = []struct {
+	cadence        model.SubscriptionCadence
+	minDays        int
+	maxDays        int
+	minOccurrences int
+}{
+	{model.SubscriptionWeekly, 5, 9, 4},
+	{model.SubscriptionMonthly, 26, 35, 3},
+	{model.SubscriptionQuarterly, 84, 98, 3},
+	{model.SubscriptionYearly, 350, 380, 2},
+}
+
+type SubscriptionService struct {
+	report       repository.ReportRepositoryInterface
+	subscription repository.SubscriptionRepositoryInterface
+}
+
+type SubscriptionServiceInterface interface {
+	Detect(userID int64) ([]*model.Subscription, error)
+	GetAll(v *validator.Validator, userID int64, status string) ([]*model.Subscription, error)
+	Confirm(v *validator.Validator, userID int64, merchant string) (*model.Subscription, error)
+	Dismiss(v *validator.Validator, userID int64, merchant string) (*model.Subscription, error)
+	Delete(id, userID int64) error
+}
+
+func NewSubscriptionService(
+	report repository.ReportRepositoryInterface,
+	subscription repository.SubscriptionRepositoryInterface,
+) *SubscriptionService {
+	return &SubscriptionService{
+		report:       report,
+		subscription: subscription,
+	}
+}
+
+func (s *SubscriptionService) Detect(userID int64) ([]*model.Subscription, error) {
+	detected, err := s.detect(userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.subscription.GetAllByUserID(userID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make(map[string]*model.Subscription, len(stored))
+	for _, subscription := range stored {
+		tracked[subscription.Merchant] = subscription
+	}
+
+	subscriptions := []*model.Subscription{}
+	for _, subscription := range detected {
+		if t, exist := tracked[subscription.Merchant]; exist {
+			if t.Status == model.SubscriptionDismissed {
+				continue
+			}
+
+			subscription.ID = t.ID
+			subscription.CreatedAt = t.CreatedAt
+			subscription.Status = t.Status
+			subscription.Version = t.Version
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *SubscriptionService) GetAll(v *validator.Validator, userID int64, status string) ([]*model.Subscription, error) {
+	subscriptionStatus := model.SubscriptionConfirmed
+	if status != "" {
+		subscriptionStatus = model.SubscriptionStatusFromString(strings.ToUpper(status))
+		if v.Check(subscriptionStatus != 0, "status", "must be CONFIRMED or DISMISSED"); !v.Valid() {
+			return nil, e.ErrInvalidData
+		}
+	}
+
+	return s.subscription.GetAllByUserID(userID, subscriptionStatus)
+}
+
+func (s *SubscriptionService) Confirm(v *validator.Validator, userID int64, merchant string) (*model.Subscription, error) {
+	return s.track(v, userID, merchant, model.SubscriptionConfirmed)
+}
+
+func (s *SubscriptionService) Dismiss(v *validator.Validator, userID int64, merchant string) (*model.Subscription, error) {
+	return s.track(v, userID, merchant, model.SubscriptionDismissed)
+}
+
+func (s *SubscriptionService) Delete(id, userID int64) error {
+	return s.subscription.Delete(id, userID)
+}
+
+func (s *SubscriptionService) track(
+	v *validator.Validator,
+	userID int64,
+	merchant string,
+	status model.SubscriptionStatus,
+) (*model.Subscription, error) {
+	merchant = merchantKey(merchant)
+	if model.ValidateSubscriptionMerchant(v, merchant); !v.Valid() {
+		return nil, e.ErrInvalidData
+	}
+
+	detected, err := s.detect(userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subscription := range detected {
+		if subscription.Merchant != merchant {
+			continue
+		}
+
+		subscription.Status = status
+		if err := s.subscription.Upsert(subscription); err != nil {
+			return nil, err
+		}
+		return subscription, nil
+	}
+
+	return nil, e.ErrRecordNotFound
+}
+
+func (s *SubscriptionService) detect(userID int64, now time.Time) ([]*model.Subscription, error) {
+	start := now.AddDate(0, -subscriptionLookbackMonths, 0)
+
+	transactions, currency, err := s.report.GetConvertedTransactions(userID, start, now)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]*model.Transaction)
+	keys := []string{}
+
+	for _, t := range transactions {
+		if t.Category.Type != model.DESPESA {
+			continue
+		}
+
+		key := merchantKey(t.Description)
+		if key == "" {
+			continue
+		}
+
+		if _, exist := groups[key]; !exist {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+
+	subscriptions := []*model.Subscription{}
+
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+
+		intervals := make([]int, 0, len(group)-1)
+		amounts := make([]money.Money, 0, len(group))
+
+		for i, t := range group {
+			amounts = append(amounts, t.Amount)
+			if i > 0 {
+				intervals = append(intervals, int(t.CreatedAt.Sub(group[i-1].CreatedAt).Hours()/24))
+			}
+		}
+
+		median := medianInt(intervals)
+
+		for _, c := range subscriptionCadences {
+			if median < c.minDays || median > c.maxDays || len(group) < c.minOccurrences {
+				continue
+			}
+
+			if !intervalsWithin(intervals, c.minDays, c.maxDays) || !amountsAreSimilar(amounts, medianMoney(amounts)) {
+				break
+			}
+
+			last := group[len(group)-1]
+			next := c.cadence.Next(last.CreatedAt)
+
+			if now.After(next.AddDate(0, 0, c.maxDays-c.minDays)) {
+				break
+			}
+
+			subscriptions = append(subscriptions, &model.Subscription{
+				User:        &model.User{ID: userID},
+				Category:    last.Category,
+				Merchant:    key,
+				Description: last.Description,
+				Cadence:     c.cadence,
+				Amount:      money.Sum(amounts...).Div(int64(len(amounts))),
+				Currency:    currency,
+				Occurrences: len(group),
+				LastCharge:  last.CreatedAt,
+				NextCharge:  next,
+			})
+			break
+		}
+	}
+
+	return subscriptions, nil
+}
+
+func intervalsWithin(intervals []int, minDays, maxDays int) bool {
+	for _, interval := range intervals {
+		if interval < minDays || interval > maxDays {
+			return false
+		}
+	}
+	return true
+}
+
+func merchantKey(description string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, description)
+
+	return strings.Join(strings.Fields(cleaned), " ")
+}
@@ -1,21 +1,27 @@
 package service
 
 import (
+	"database/sql"
 	"financas/internal/model"
 	"financas/internal/model/filters"
 	"financas/internal/repository"
+	"financas/utils"
 	e "financas/utils/errors"
 	"financas/utils/validator"
 	"time"
 )
 
 type TransactionService struct {
-	Transaction repository.TransactionRepositoryInterface
+	Transaction  repository.TransactionRepositoryInterface
+	goalProgress GoalProgressServiceInterface
+	db           *sql.DB
 }
 
-func NewTransactionService(r repository.TransactionRepositoryInterface) *TransactionService {
+func NewTransactionService(r repository.TransactionRepositoryInterface, goalProgress GoalProgressServiceInterface, db *sql.DB) *TransactionService {
 	return &TransactionService{
-		Transaction: r,
+		Transaction:  r,
+		goalProgress: goalProgress,
+		db:           db,
 	}
 }
 
@@ -24,7 +30,7 @@ type TransactionServiceInterface interface {
 	GetAllByUserAndCategory(v *validator.Validator, description string, userID int64, categoryID int64, startDate, endDate *time.Time, f filters.Filters) ([]*model.Transaction, filters.Metadata, error)
 	Save(v *validator.Validator, t *model.Transaction) error
 	Update(v *validator.Validator, t *model.Transaction, userID int64) error
-	Delete(id, userID int64) error
+	Delete(v *validator.Validator, id, userID int64) error
 }
 
 func (s *TransactionService) GetByID(id, userID int64) (*model.Transaction, error) {
@@ -49,7 +55,9 @@ func (s *TransactionService) Save(v *validator.Validator, t *model.Transaction)
 		return e.ErrInvalidData
 	}
 
-	return s.Transaction.Insert(t)
+	return utils.RunInTx(s.db, func(tx *sql.Tx) error {
+		return s.Transaction.Insert(t, tx)
+	})
 }
 
 func (s *TransactionService) Update(v *validator.Validator, t *model.Transaction, userID int64) error {
@@ -57,13 +65,25 @@ func (s *TransactionService) Update(v *validator.Validator, t *model.Transaction
 		return e.ErrRecordNotFound
 	}
 
+	if t.Currency == "" {
+		current, err := s.Transaction.GetByID(t.ID, userID)
+		if err != nil {
+			return err
+		}
+		t.Currency = current.Currency
+	}
+
 	if t.ValidateTransaction(v); !v.Valid() {
 		return e.ErrInvalidData
 	}
 
-	return s.Transaction.Update(t)
+	return s.goalProgress.SyncTransaction(v, t, userID, func(tx *sql.Tx) error {
+		return s.Transaction.Update(t, tx)
+	})
 }
 
-func (s *TransactionService) Delete(id, userID int64) error {
-	return s.Transaction.Delete(id, userID)
+func (s *TransactionService) Delete(v *validator.Validator, id, userID int64) error {
+	return s.goalProgress.RemoveTransaction(v, id, userID, func(tx *sql.Tx) error {
+		return s.Transaction.Delete(id, userID, tx)
+	})
 }
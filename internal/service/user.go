@@ -20,6 +20,7 @@ type UserServiceInterface interface {
 	Insert(user *model.User, v *validator.Validator) error
 	RegisterUserHandler(user *model.User, v *validator.Validator) error
 	GetUserByEmail(email string, v *validator.Validator) (*model.User, error)
+	UpdateBaseCurrency(v *validator.Validator, user *model.User, currency string) error
 }
 
 func NewUserService(repo repository.UserRepository) *UserService {
@@ -38,7 +39,9 @@ func (s *UserService) GetUserByEmail(email string, v *validator.Validator) (*mod
 }
 
 func (s *UserService) ActivateUser(cod int, email string, v *validator.Validator) (*model.User, error) {
-	if model.ValidateEmail(v, email); !v.Valid() {
+	model.ValidateEmail(v, email)
+	v.Check(cod != 0, "code", "must be provided")
+	if !v.Valid() {
 		return nil, e.ErrInvalidData
 	}
 
@@ -102,3 +105,13 @@ func (s *UserService) Insert(user *model.User, v *validator.Validator) error {
 	}
 	return nil
 }
+
+func (s *UserService) UpdateBaseCurrency(v *validator.Validator, user *model.User, currency string) error {
+	currency = model.NormalizeCurrency(currency)
+	if model.ValidateCurrency(v, "base_currency", currency); !v.Valid() {
+		return e.ErrInvalidData
+	}
+
+	user.BaseCurrency = currency
+	return s.Update(user)
+}
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN role SMALLINT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id BIGSERIAL PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS roles_permissions (
+    role SMALLINT NOT NULL,
+    permission_id BIGINT NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission_id)
+);
+
+INSERT INTO permissions (code)
+VALUES
+    ('users:read'),
+    ('users:write'),
+    ('stats:read');
+
+INSERT INTO roles_permissions (role, permission_id)
+SELECT 2, id FROM permissions;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS roles_permissions;
+DROP TABLE IF EXISTS permissions;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN base_currency CHAR(3) NOT NULL DEFAULT 'BRL';
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+ALTER TABLE goals ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_currency CHAR(3) NOT NULL,
+    to_currency CHAR(3) NOT NULL,
+    rate NUMERIC(18,8) NOT NULL CHECK (rate > 0),
+    date DATE NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1,
+    CONSTRAINT unique_user_exchange_rate UNIQUE (user_id, from_currency, to_currency, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_user_id ON exchange_rates(user_id);
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_lookup ON exchange_rates(user_id, from_currency, to_currency, date DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exchange_rates;
+ALTER TABLE goals DROP COLUMN IF EXISTS currency;
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE users DROP COLUMN IF EXISTS base_currency;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL,
+    merchant VARCHAR(500) NOT NULL,
+    description VARCHAR(500) NOT NULL,
+    cadence SMALLINT NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    last_charge DATE NOT NULL,
+    next_charge DATE NOT NULL,
+    status SMALLINT NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1,
+    CONSTRAINT unique_user_subscription UNIQUE (user_id, merchant)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS assets (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    class SMALLINT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS asset_valuations (
+    id BIGSERIAL PRIMARY KEY,
+    asset_id BIGINT NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    value NUMERIC(15,2) NOT NULL CHECK (value >= 0),
+    date DATE NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1,
+    CONSTRAINT unique_asset_valuation_date UNIQUE (asset_id, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assets_user_id ON assets(user_id) WHERE NOT deleted;
+CREATE INDEX IF NOT EXISTS idx_asset_valuations_lookup ON asset_valuations(asset_id, date DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS asset_valuations;
+DROP TABLE IF EXISTS assets;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS loans (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    system SMALLINT NOT NULL,
+    principal NUMERIC(15,2) NOT NULL CHECK (principal > 0),
+    annual_rate NUMERIC(9,4) NOT NULL CHECK (annual_rate >= 0),
+    term INTEGER NOT NULL CHECK (term > 0),
+    start_date DATE NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS loan_prepayments (
+    id BIGSERIAL PRIMARY KEY,
+    loan_id BIGINT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    amount NUMERIC(15,2) NOT NULL CHECK (amount > 0),
+    date DATE NOT NULL,
+    mode SMALLINT NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS loan_payments (
+    id BIGSERIAL PRIMARY KEY,
+    loan_id BIGINT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    installment INTEGER NOT NULL CHECK (installment > 0),
+    transaction_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_loan_installment UNIQUE (loan_id, installment),
+    CONSTRAINT unique_loan_payment_transaction UNIQUE (transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_loans_user_id ON loans(user_id) WHERE NOT deleted;
+CREATE INDEX IF NOT EXISTS idx_loan_prepayments_loan_id ON loan_prepayments(loan_id, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS loan_payments;
+DROP TABLE IF EXISTS loan_prepayments;
+DROP TABLE IF EXISTS loans;
+-- +goose StatementEnd
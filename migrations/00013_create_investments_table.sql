@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS investments (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ticker VARCHAR(32),
+    name VARCHAR(255) NOT NULL,
+    kind SMALLINT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS investment_operations (
+    id BIGSERIAL PRIMARY KEY,
+    investment_id BIGINT NOT NULL REFERENCES investments(id) ON DELETE CASCADE,
+    type SMALLINT NOT NULL,
+    date DATE NOT NULL,
+    quantity NUMERIC(24,8) NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    price NUMERIC(15,2) NOT NULL DEFAULT 0 CHECK (price >= 0),
+    fees NUMERIC(15,2) NOT NULL DEFAULT 0 CHECK (fees >= 0),
+    amount NUMERIC(15,2) NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS investment_prices (
+    id BIGSERIAL PRIMARY KEY,
+    investment_id BIGINT NOT NULL REFERENCES investments(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    price NUMERIC(15,2) NOT NULL CHECK (price >= 0),
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_investment_price_date UNIQUE (investment_id, date)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_user_investment_ticker ON investments(user_id, ticker) WHERE NOT deleted AND ticker IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_investment_operations_lookup ON investment_operations(investment_id, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS investment_prices;
+DROP TABLE IF EXISTS investment_operations;
+DROP TABLE IF EXISTS investments;
+-- +goose StatementEnd
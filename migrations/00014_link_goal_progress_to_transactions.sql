@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goal_progress
+    ADD COLUMN IF NOT EXISTS transaction_id BIGINT REFERENCES transactions(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS transaction_created BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_goal_progress_transaction
+    ON goal_progress(transaction_id)
+    WHERE deleted = false AND transaction_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS unique_goal_progress_transaction;
+
+ALTER TABLE goal_progress
+    DROP COLUMN IF EXISTS transaction_created,
+    DROP COLUMN IF EXISTS transaction_id;
+-- +goose StatementEnd
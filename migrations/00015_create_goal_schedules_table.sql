@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS goal_schedules (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    goal_id BIGINT NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    mode SMALLINT NOT NULL,
+    amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    day_of_month SMALLINT NOT NULL CHECK (day_of_month BETWEEN 1 AND 28),
+    next_run DATE NOT NULL,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS goal_schedule_runs (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id BIGINT NOT NULL REFERENCES goal_schedules(id) ON DELETE CASCADE,
+    due_date DATE NOT NULL,
+    status SMALLINT NOT NULL,
+    amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    goal_progress_id BIGINT REFERENCES goal_progress(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_goal_schedule_run UNIQUE (schedule_id, due_date)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_goal_schedule_goal ON goal_schedules(goal_id) WHERE NOT deleted;
+CREATE INDEX IF NOT EXISTS idx_goal_schedules_next_run ON goal_schedules(next_run) WHERE NOT deleted;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS goal_schedule_runs;
+DROP TABLE IF EXISTS goal_schedules;
+-- +goose StatementEnd
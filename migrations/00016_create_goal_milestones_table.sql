@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS goal_milestones (
+    id BIGSERIAL PRIMARY KEY,
+    goal_id BIGINT NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    kind SMALLINT NOT NULL,
+    amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    percentage NUMERIC(5,2) NOT NULL DEFAULT 0,
+    target_date DATE,
+    reached_at TIMESTAMP(0) WITH TIME ZONE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS goal_events (
+    id BIGSERIAL PRIMARY KEY,
+    goal_id BIGINT NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    type SMALLINT NOT NULL,
+    milestone_id BIGINT REFERENCES goal_milestones(id) ON DELETE SET NULL,
+    amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_milestones_goal_id ON goal_milestones(goal_id) WHERE NOT deleted;
+CREATE INDEX IF NOT EXISTS idx_goal_events_goal_id ON goal_events(goal_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS goal_events;
+DROP TABLE IF EXISTS goal_milestones;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goals
+    ADD COLUMN IF NOT EXISTS annual_rate NUMERIC(9,4) CHECK (annual_rate >= 0);
+
+CREATE TABLE IF NOT EXISTS interest_rates (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    annual_rate NUMERIC(9,4) NOT NULL CHECK (annual_rate >= 0),
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1,
+    CONSTRAINT unique_user_interest_rate_month UNIQUE (user_id, month)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS interest_rates;
+
+ALTER TABLE goals
+    DROP COLUMN IF EXISTS annual_rate;
+-- +goose StatementEnd
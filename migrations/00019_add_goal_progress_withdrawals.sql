@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goal_progress
+    ADD COLUMN IF NOT EXISTS kind SMALLINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
+
+UPDATE goal_progress SET kind = 2 WHERE amount < 0;
+
+UPDATE goals SET current = 0 WHERE current < 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goal_progress
+    DROP COLUMN IF EXISTS reason,
+    DROP COLUMN IF EXISTS kind;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS goal_members (
+    id BIGSERIAL PRIMARY KEY,
+    goal_id BIGINT NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invited_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status SMALLINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMP(0) WITH TIME ZONE,
+    CONSTRAINT unique_goal_member UNIQUE (goal_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_members_user_id ON goal_members(user_id, status);
+
+ALTER TABLE goal_progress
+    ADD COLUMN IF NOT EXISTS user_id BIGINT REFERENCES users(id);
+
+UPDATE goal_progress gp
+SET user_id = g.user_id
+FROM goals g
+WHERE gp.goal_id = g.id AND gp.user_id IS NULL;
+
+ALTER TABLE goal_progress ALTER COLUMN user_id SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goal_progress DROP COLUMN IF EXISTS user_id;
+
+DROP TABLE IF EXISTS goal_members;
+-- +goose StatementEnd
@@ -19,6 +19,15 @@ var (
 	ErrInvalidCredentials    = errors.New("invalid authentication credentials")
 	ErrInactiveAccount       = errors.New("your user account must be activated to access this resource")
 	ErrStartDateAfterEndDate = errors.New("start date must be before end date")
+	ErrNotPermitted          = errors.New("not permitted")
+	ErrDuplicateExchangeRate = errors.New("duplicate exchange rate")
+	ErrExchangeRateNotFound  = errors.New("exchange rate not found")
+	ErrDuplicateLoanPayment  = errors.New("duplicate loan payment")
+	ErrInstallmentPaid       = errors.New("installment already paid")
+	ErrDuplicateTicker       = errors.New("duplicate ticker")
+	ErrTransactionLinked     = errors.New("transaction already linked")
+	ErrDuplicateGoalSchedule = errors.New("duplicate goal schedule")
+	ErrDuplicateGoalMember   = errors.New("duplicate goal member")
 )
 
 type ErrorResponse struct {
@@ -65,12 +74,46 @@ func (e *ErrorResponse) HandlerErrorResponse(w http.ResponseWriter, r *http.Requ
 		v.AddError("phone", "a register with this phone number already exists")
 		e.FailedValidationResponse(w, r, v.Errors)
 
+	case errors.Is(err, ErrDuplicateTicker) && v != nil:
+		v.AddError("ticker", "an investment with this ticker already exists")
+		e.FailedValidationResponse(w, r, v.Errors)
+
+	case errors.Is(err, ErrDuplicateExchangeRate) && v != nil:
+		v.AddError("date", "an exchange rate for this currency pair and date already exists")
+		e.FailedValidationResponse(w, r, v.Errors)
+
+	case errors.Is(err, ErrExchangeRateNotFound) && v != nil:
+		v.AddError("exchange_rate", err.Error())
+		e.FailedValidationResponse(w, r, v.Errors)
+
+	case errors.Is(err, ErrDuplicateLoanPayment) && v != nil:
+		v.AddError("transaction_id", "this transaction is already linked to a loan installment")
+		e.FailedValidationResponse(w, r, v.Errors)
+
+	case errors.Is(err, ErrDuplicateGoalSchedule) && v != nil:
+		v.AddError("goal_id", "this goal already has a contribution schedule")
+		e.FailedValidationResponse(w, r, v.Errors)
+
+	case errors.Is(err, ErrDuplicateGoalMember) && v != nil:
+		v.AddError("email", "this user has already been invited to the goal")
+		e.FailedValidationResponse(w, r, v.Errors)
+
+	case errors.Is(err, ErrTransactionLinked) && v != nil:
+		v.AddError("transaction_id", "this transaction is already linked to a goal contribution")
+		e.FailedValidationResponse(w, r, v.Errors)
+
 	case errors.Is(err, ErrEditConflict):
 		e.EditConflictResponse(w, r)
 
+	case errors.Is(err, ErrInstallmentPaid):
+		e.errorResponse(w, r, http.StatusConflict, "this installment has already been paid")
+
 	case errors.Is(err, ErrInactiveAccount):
 		e.InactiveAccountResponse(w, r)
 
+	case errors.Is(err, ErrNotPermitted):
+		e.NotPermittedResponse(w, r)
+
 	default:
 		e.ServerErrorResponse(w, r, err)
 	}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"financas/internal/model/money"
 	"financas/utils/validator"
 	"fmt"
 	"io"
@@ -86,6 +87,36 @@ func ReadInt(qs url.Values, key string, defaultValue int, v *validator.Validator
 	return i
 }
 
+func ReadMoney(qs url.Values, key string, defaultValue money.Money, v *validator.Validator) money.Money {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	m, err := money.Parse(s)
+	if err != nil {
+		v.AddError(key, "must be a valid amount")
+		return defaultValue
+	}
+	return m
+}
+
+func ReadFloat(qs url.Values, key string, defaultValue float64, v *validator.Validator) float64 {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		v.AddError(key, "must be a number")
+		return defaultValue
+	}
+	return f
+}
+
 func ReadJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
 	maxBytes := 1_048_576
 	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBytes))